@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"rcrt/internal/bootstrap"
+	"rcrt/internal/machine"
+)
+
+// runBootstrap applies the bootstrap-breadcrumbs manifests against the
+// RCRT HTTP API, resuming from the BoltDB state file rather than
+// re-running everything (or trusting a `.bootstrapped` marker file).
+// onStatus receives the same step progress that's logged, so the tray's
+// status label can track bootstrap progress instead of sitting on
+// "Starting..." for the whole run.
+func runBootstrap(ctx context.Context, onStatus machine.StatusFunc) error {
+	stateDB := filepath.Join(basePath, "bootstrap-state.db")
+	state, err := bootstrap.OpenStateStore(stateDB)
+	if err != nil {
+		return fmt.Errorf("opening bootstrap state: %w", err)
+	}
+	defer state.Close()
+
+	if resetBootstrap {
+		log.Println("Resetting bootstrap state (--reset-bootstrap)...")
+		if err := state.Reset(); err != nil {
+			return fmt.Errorf("resetting bootstrap state: %w", err)
+		}
+	}
+
+	breadcrumbsDir := filepath.Join(basePath, "bootstrap-breadcrumbs")
+	steps, err := bootstrap.LoadManifests(breadcrumbsDir)
+	if err != nil {
+		return fmt.Errorf("loading bootstrap breadcrumbs: %w", err)
+	}
+	if len(steps) == 0 {
+		log.Println("No bootstrap breadcrumbs found, skipping")
+		return nil
+	}
+
+	if reapplyBootstrap {
+		log.Println("Re-applying every bootstrap step (--reapply)...")
+		if err := state.Reset(); err != nil {
+			return fmt.Errorf("clearing bootstrap state for reapply: %w", err)
+		}
+	}
+
+	log.Println("🌱 Bootstrapping RCRT system...")
+	log.Println("   This creates agents, tools, and system configuration...")
+
+	runner, err := bootstrap.NewRunner(steps, bootstrap.Options{
+		BaseURL: "http://localhost:8081", // External port mapping
+		State:   state,
+		OnProgress: func(done, total int, message string) {
+			log.Printf("Bootstrapping: %d/%d - %s", done, total, message)
+			onStatus(fmt.Sprintf("Bootstrapping: %d/%d - %s", done, total, message))
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("building bootstrap runner: %w", err)
+	}
+
+	if err := runner.Run(ctx); err != nil {
+		return fmt.Errorf("bootstrap failed: %w", err)
+	}
+
+	log.Println("✓ Bootstrap complete")
+	return nil
+}
@@ -0,0 +1,104 @@
+//go:build darwin
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+const plistTemplate = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>%s</string>
+	<key>ProgramArguments</key>
+	<array>
+		<string>%s</string>
+		<string>service</string>
+		<string>-socket</string>
+		<string>%s</string>
+	</array>
+	<key>RunAtLoad</key>
+	<true/>
+	<key>KeepAlive</key>
+	<true/>
+	<key>StandardOutPath</key>
+	<string>/var/log/%s.log</string>
+	<key>StandardErrorPath</key>
+	<string>/var/log/%s.log</string>
+</dict>
+</plist>
+`
+
+// install writes the LaunchDaemon plist and loads it. It refuses to run
+// unless the binary being installed lives in a root-owned, non-user
+// writable path, since a launchd daemon running as root must not execute
+// code a regular user could swap out from under it. sockDst is baked into
+// the plist's ProgramArguments so the daemon keeps forwarding to the same
+// path on every boot.
+func install(sockDst string) error {
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating executable: %w", err)
+	}
+	self, err = filepath.EvalSymlinks(self)
+	if err != nil {
+		return fmt.Errorf("resolving executable path: %w", err)
+	}
+
+	if err := requireRootOwnedNotUserWritable(self); err != nil {
+		return fmt.Errorf("refusing to install: %w", err)
+	}
+
+	if os.Geteuid() != 0 {
+		return fmt.Errorf("install must run as root (use sudo or administrator privileges)")
+	}
+
+	contents := fmt.Sprintf(plistTemplate, helperLabel, self, sockDst, helperLabel, helperLabel)
+	if err := os.WriteFile(plistPath, []byte(contents), 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", plistPath, err)
+	}
+
+	if out, err := exec.Command("launchctl", "load", "-w", plistPath).CombinedOutput(); err != nil {
+		return fmt.Errorf("launchctl load: %w: %s", err, out)
+	}
+
+	return nil
+}
+
+// uninstall unloads and removes the LaunchDaemon.
+func uninstall() error {
+	if os.Geteuid() != 0 {
+		return fmt.Errorf("uninstall must run as root (use sudo or administrator privileges)")
+	}
+
+	exec.Command("launchctl", "unload", "-w", plistPath).Run()
+
+	if err := os.Remove(plistPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing %s: %w", plistPath, err)
+	}
+	return nil
+}
+
+// requireRootOwnedNotUserWritable ensures path and every directory above
+// it up to root is owned by root and not group/world writable, so a
+// non-root user cannot redirect what this privileged daemon executes.
+func requireRootOwnedNotUserWritable(path string) error {
+	for dir := path; dir != "/" && dir != "."; dir = filepath.Dir(dir) {
+		info, err := os.Lstat(dir)
+		if err != nil {
+			return fmt.Errorf("stat %s: %w", dir, err)
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("%s is a symlink, refusing", dir)
+		}
+		if err := checkOwnerAndMode(dir, info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
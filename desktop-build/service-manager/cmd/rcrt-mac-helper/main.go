@@ -0,0 +1,47 @@
+//go:build darwin
+
+// Command rcrt-mac-helper bridges /var/run/docker.sock to the current
+// user's Podman machine socket, the way upstream Podman's
+// podman-mac-helper bridges Docker-compatible tooling on macOS.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+const (
+	helperLabel    = "io.rcrt.helper"
+	plistPath      = "/Library/LaunchDaemons/" + helperLabel + ".plist"
+	defaultSockDst = "/var/run/docker.sock"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "usage: rcrt-mac-helper <install|uninstall|service> [-socket path]")
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	sockDst := fs.String("socket", defaultSockDst, "path the helper forwards to the Podman machine socket")
+	fs.Parse(os.Args[2:])
+
+	var err error
+	switch os.Args[1] {
+	case "install":
+		err = install(*sockDst)
+	case "uninstall":
+		err = uninstall()
+	case "service":
+		err = runService(*sockDst)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown subcommand %q\n", os.Args[1])
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatalf("rcrt-mac-helper %s: %v", os.Args[1], err)
+	}
+}
@@ -0,0 +1,106 @@
+//go:build darwin
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strings"
+)
+
+// runService forwards every connection on dstPath (normally
+// /var/run/docker.sock) to the current user's Podman machine socket, so
+// Docker-compatible tooling that only knows about docker.sock keeps
+// working against Podman.
+func runService(dstPath string) error {
+	target, err := resolvePodmanSocket()
+	if err != nil {
+		return fmt.Errorf("resolving podman socket: %w", err)
+	}
+
+	os.Remove(dstPath)
+	listener, err := net.Listen("unix", dstPath)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", dstPath, err)
+	}
+	defer listener.Close()
+	os.Chmod(dstPath, 0666)
+
+	log.Printf("rcrt-mac-helper: forwarding %s -> %s", dstPath, target)
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return fmt.Errorf("accept: %w", err)
+		}
+		go forward(conn, target)
+	}
+}
+
+// forward proxies one accepted connection to the Podman socket. The target
+// path is re-resolved fresh on every connection, so a symlink swapped in
+// after the helper started can't silently redirect a privileged
+// connection to somewhere outside the expected machine directory.
+func forward(client net.Conn, target string) {
+	defer client.Close()
+
+	resolved, err := filepath.EvalSymlinks(target)
+	if err != nil {
+		log.Printf("rcrt-mac-helper: resolving target: %v", err)
+		return
+	}
+	expectedDir := filepath.Dir(target)
+	if filepath.Dir(resolved) != expectedDir {
+		log.Printf("rcrt-mac-helper: refusing forward, %s resolves outside %s", target, expectedDir)
+		return
+	}
+
+	upstream, err := net.Dial("unix", resolved)
+	if err != nil {
+		log.Printf("rcrt-mac-helper: dialing podman socket: %v", err)
+		return
+	}
+	defer upstream.Close()
+
+	done := make(chan struct{}, 2)
+	go func() { io.Copy(upstream, client); done <- struct{}{} }()
+	go func() { io.Copy(client, upstream); done <- struct{}{} }()
+	<-done
+}
+
+// resolvePodmanSocket returns the logged-in console user's Podman machine
+// socket path, mirroring the layout `podman machine` uses on macOS. This
+// runs inside a root LaunchDaemon with no console session of its own, so
+// os.UserHomeDir() would resolve to root's home (e.g. /var/root) rather
+// than the real user's — the console user has to be looked up explicitly.
+func resolvePodmanSocket() (string, error) {
+	username, err := consoleUser()
+	if err != nil {
+		return "", fmt.Errorf("resolving console user: %w", err)
+	}
+	u, err := user.Lookup(username)
+	if err != nil {
+		return "", fmt.Errorf("looking up user %s: %w", username, err)
+	}
+	return filepath.Join(u.HomeDir, ".local", "share", "containers", "podman", "machine", "podman.sock"), nil
+}
+
+// consoleUser returns the name of the user owning the active console
+// session, i.e. whoever is actually logged into the GUI.
+func consoleUser() (string, error) {
+	out, err := exec.Command("stat", "-f", "%Su", "/dev/console").Output()
+	if err != nil {
+		return "", err
+	}
+	name := strings.TrimSpace(string(out))
+	if name == "" || name == "root" {
+		return "", fmt.Errorf("no console user logged in")
+	}
+	return name, nil
+}
@@ -0,0 +1,25 @@
+//go:build darwin
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// checkOwnerAndMode reports an error if dir is not owned by root or is
+// writable by anyone other than root.
+func checkOwnerAndMode(dir string, info os.FileInfo) error {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fmt.Errorf("%s: could not read owner", dir)
+	}
+	if stat.Uid != 0 {
+		return fmt.Errorf("%s is not owned by root", dir)
+	}
+	if info.Mode().Perm()&0022 != 0 {
+		return fmt.Errorf("%s is group- or world-writable", dir)
+	}
+	return nil
+}
@@ -8,57 +8,117 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"rcrt/internal/secrets"
 )
 
-// ensureValidEnv checks .env file and generates KEK if needed
+const kekRefPrefix = "LOCAL_KEK_REF="
+
+// ensureValidEnv checks .env file and generates KEK if needed. The KEK
+// itself is sealed with the OS-native keystore: .env only ever holds an
+// opaque reference to it, never the key material.
 func ensureValidEnv() error {
 	envPath := filepath.Join(basePath, ".env")
-	
+
 	// Read .env file
 	data, err := os.ReadFile(envPath)
 	if err != nil {
 		log.Printf("⚠️  Could not read .env: %v", err)
 		return nil // Non-fatal
 	}
-	
+
 	content := string(data)
-	
+	store := secrets.New()
+
+	// Migrate a pre-existing plaintext KEK: seal it and rewrite .env with
+	// the reference form instead.
+	if key, line, found := findPlaintextKEK(content); found {
+		log.Println("Migrating plaintext encryption key to OS keystore...")
+		ref, err := store.Seal(key)
+		if err != nil {
+			return fmt.Errorf("sealing existing KEK: %w", err)
+		}
+		content = strings.Replace(content, line, kekRefPrefix+ref, 1)
+		if err := os.WriteFile(envPath, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write .env: %w", err)
+		}
+		log.Println("✓ Encryption key migrated to OS keystore")
+		return nil
+	}
+
 	// Check if KEK needs to be generated
 	if strings.Contains(content, "LOCAL_KEK_BASE64=your-base64-encoded-key-here") ||
-	   strings.Contains(content, "LOCAL_KEK_BASE64=your-") {
+		strings.Contains(content, "LOCAL_KEK_BASE64=your-") {
 		log.Println("Generating encryption key for secrets...")
-		
+
 		// Generate 32 random bytes
 		key := make([]byte, 32)
 		if _, err := rand.Read(key); err != nil {
 			return fmt.Errorf("failed to generate key: %w", err)
 		}
-		
-		// Encode to base64
-		keyBase64 := base64.StdEncoding.EncodeToString(key)
-		
-		// Replace placeholder in content
-		content = strings.ReplaceAll(content, 
+
+		ref, err := store.Seal(key)
+		if err != nil {
+			return fmt.Errorf("sealing new KEK: %w", err)
+		}
+
+		// Replace placeholder in content with the opaque reference, not
+		// the key material.
+		content = strings.ReplaceAll(content,
 			"LOCAL_KEK_BASE64=your-base64-encoded-key-here",
-			"LOCAL_KEK_BASE64="+keyBase64)
+			kekRefPrefix+ref)
 		content = strings.ReplaceAll(content,
 			"LOCAL_KEK_BASE64=your-",
-			"LOCAL_KEK_BASE64="+keyBase64)
-		
+			kekRefPrefix+ref)
+
 		// Write updated .env
 		if err := os.WriteFile(envPath, []byte(content), 0644); err != nil {
 			return fmt.Errorf("failed to write .env: %w", err)
 		}
-		
-		log.Println("✓ Encryption key generated and saved to .env")
-		log.Printf("   Key: %s... (saved to .env)", keyBase64[:20])
+
+		log.Println("✓ Encryption key generated and sealed in OS keystore")
 	}
-	
+
 	return nil
 }
 
+// findPlaintextKEK reports whether content still has a plaintext
+// LOCAL_KEK_BASE64 value (from before this keystore migration landed),
+// returning the decoded key and the exact line to replace.
+func findPlaintextKEK(content string) (key []byte, line string, found bool) {
+	for _, l := range strings.Split(content, "\n") {
+		value, ok := strings.CutPrefix(l, "LOCAL_KEK_BASE64=")
+		if !ok || value == "" || strings.HasPrefix(value, "your-") {
+			continue
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+		if err != nil || len(key) == 0 {
+			continue
+		}
+		return key, l, true
+	}
+	return nil, "", false
+}
 
-
-
-
-
+// resolveKEK unseals the LOCAL_KEK_REF in .env and returns the plaintext
+// base64 KEK, for injection into the compose child process's environment
+// rather than persisting it to disk.
+func resolveKEK() (string, error) {
+	envPath := filepath.Join(basePath, ".env")
+	data, err := os.ReadFile(envPath)
+	if err != nil {
+		return "", fmt.Errorf("reading .env: %w", err)
+	}
+	for _, l := range strings.Split(string(data), "\n") {
+		ref, ok := strings.CutPrefix(l, kekRefPrefix)
+		if !ok {
+			continue
+		}
+		plaintext, err := secrets.New().Unseal(strings.TrimSpace(ref))
+		if err != nil {
+			return "", fmt.Errorf("unsealing KEK: %w", err)
+		}
+		return base64.StdEncoding.EncodeToString(plaintext), nil
+	}
+	return "", fmt.Errorf("LOCAL_KEK_REF not found in .env")
+}
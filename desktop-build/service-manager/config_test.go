@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+)
+
+func TestFindPlaintextKEK(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	encoded := base64.StdEncoding.EncodeToString(key)
+
+	tests := []struct {
+		name      string
+		content   string
+		wantFound bool
+		wantLine  string
+	}{
+		{
+			name:      "plaintext key present",
+			content:   "FOO=bar\nLOCAL_KEK_BASE64=" + encoded + "\nBAZ=qux",
+			wantFound: true,
+			wantLine:  "LOCAL_KEK_BASE64=" + encoded,
+		},
+		{
+			name:      "placeholder is not a plaintext key",
+			content:   "LOCAL_KEK_BASE64=your-base64-encoded-key-here",
+			wantFound: false,
+		},
+		{
+			name:      "already migrated to a reference",
+			content:   "LOCAL_KEK_REF=keychain:rcrt-kek",
+			wantFound: false,
+		},
+		{
+			name:      "empty value",
+			content:   "LOCAL_KEK_BASE64=",
+			wantFound: false,
+		},
+		{
+			name:      "not valid base64",
+			content:   "LOCAL_KEK_BASE64=not-valid-base64!!!",
+			wantFound: false,
+		},
+		{
+			name:      "whitespace-only value decodes to zero-length key",
+			content:   "LOCAL_KEK_BASE64=   ",
+			wantFound: false,
+		},
+		{
+			name:      "no env content at all",
+			content:   "",
+			wantFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, line, found := findPlaintextKEK(tt.content)
+			if found != tt.wantFound {
+				t.Fatalf("found = %v, want %v", found, tt.wantFound)
+			}
+			if !tt.wantFound {
+				return
+			}
+			if line != tt.wantLine {
+				t.Errorf("line = %q, want %q", line, tt.wantLine)
+			}
+			decoded, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				t.Fatalf("test setup: %v", err)
+			}
+			if !bytes.Equal(key, decoded) {
+				t.Errorf("key = %x, want %x", key, decoded)
+			}
+		})
+	}
+}
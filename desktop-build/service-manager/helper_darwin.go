@@ -0,0 +1,44 @@
+//go:build darwin
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/systray"
+)
+
+// addMacHelperMenuItem offers to install the rcrt-mac-helper launchd
+// daemon that bridges /var/run/docker.sock to the Podman machine socket,
+// or returns nil if the helper binary isn't bundled alongside this build —
+// matching helperClickedCh's nil-means-absent handling, so an unbundled
+// helper hides the entry instead of offering an install that can only
+// fail with an exec error.
+func addMacHelperMenuItem() *systray.MenuItem {
+	if _, err := os.Stat(filepath.Join(basePath, "rcrt-mac-helper")); err != nil {
+		return nil
+	}
+	return systray.AddMenuItem("Install Docker socket bridge (requires admin)",
+		"Forwards /var/run/docker.sock to the Podman machine socket")
+}
+
+// installMacHelper elevates via osascript and runs the bundled helper's
+// install subcommand.
+func installMacHelper() error {
+	helperPath := filepath.Join(basePath, "rcrt-mac-helper")
+	script := fmt.Sprintf(`do shell script "%s install" with administrator privileges`, shellQuote(helperPath))
+	if out, err := exec.Command("osascript", "-e", script).CombinedOutput(); err != nil {
+		return fmt.Errorf("osascript: %w: %s", err, out)
+	}
+	return nil
+}
+
+// shellQuote wraps path in single quotes so it survives as one argument when
+// `do shell script` hands it off to /bin/sh -c, even if it contains spaces.
+func shellQuote(path string) string {
+	return "'" + strings.ReplaceAll(path, "'", `'\''`) + "'"
+}
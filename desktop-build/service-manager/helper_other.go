@@ -0,0 +1,11 @@
+//go:build !darwin
+
+package main
+
+import "fyne.io/systray"
+
+// addMacHelperMenuItem is a no-op outside macOS: the docker.sock bridge
+// helper only exists there.
+func addMacHelperMenuItem() *systray.MenuItem { return nil }
+
+func installMacHelper() error { return nil }
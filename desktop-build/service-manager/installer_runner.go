@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"fyne.io/systray"
+
+	"rcrt/internal/installer"
+)
+
+// installPodman runs the platform installer when findPodman comes up
+// empty, streaming progress through the tray tooltip rather than just
+// logging that the user may need to restart their machine.
+func installPodman(ctx context.Context) error {
+	inst := installer.New()
+
+	installed, err := inst.Detect(ctx)
+	if err != nil {
+		return fmt.Errorf("detecting podman: %w", err)
+	}
+	if installed {
+		return nil
+	}
+
+	log.Println("Podman not found, running first-run installer...")
+	err = inst.Install(ctx, func(stage string, done, total int64) {
+		systray.SetTooltip(fmt.Sprintf("RCRT - %s (%d/%d)", stage, done, total))
+	})
+	systray.SetTooltip("RCRT - Podman Services")
+	if err != nil {
+		return fmt.Errorf("installing podman: %w", err)
+	}
+
+	log.Println("✓ Podman installed")
+	return nil
+}
@@ -0,0 +1,284 @@
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ProgressFunc reports "done/total" progress to the caller, e.g. to drive
+// the tray's "Bootstrapping: 7/23 - <step id>" label. message is the ID of
+// the step that just completed, or "resuming" for the initial report.
+type ProgressFunc func(done, total int, message string)
+
+// Options configures a Runner.
+type Options struct {
+	BaseURL     string
+	State       *StateStore
+	Concurrency int
+	OnProgress  ProgressFunc
+}
+
+// Runner executes a set of Steps in topological order (by DependsOn) with
+// bounded concurrency, applying each against the RCRT HTTP API and
+// skipping any the State store already marked complete.
+type Runner struct {
+	steps   map[string]Step
+	order   []string
+	opts    Options
+	client  *http.Client
+	doneMu  sync.Mutex
+	doneSet map[string]bool
+}
+
+// NewRunner validates the dependency graph and returns a Runner ready to
+// Run, or an error if it contains a cycle or a dangling dependency.
+func NewRunner(steps []Step, opts Options) (*Runner, error) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 4
+	}
+	if opts.OnProgress == nil {
+		opts.OnProgress = func(int, int, string) {}
+	}
+
+	byID := make(map[string]Step, len(steps))
+	for _, s := range steps {
+		if _, dup := byID[s.ID]; dup {
+			return nil, fmt.Errorf("duplicate step id %q", s.ID)
+		}
+		byID[s.ID] = s
+	}
+	for _, s := range steps {
+		for _, dep := range s.DependsOn {
+			if _, ok := byID[dep]; !ok {
+				return nil, fmt.Errorf("step %q depends on unknown step %q", s.ID, dep)
+			}
+		}
+	}
+
+	order, err := topoSort(byID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Runner{
+		steps:   byID,
+		order:   order,
+		opts:    opts,
+		client:  &http.Client{Timeout: 60 * time.Second},
+		doneSet: make(map[string]bool),
+	}, nil
+}
+
+// topoSort returns step IDs in dependency order, erroring on a cycle.
+func topoSort(byID map[string]Step) ([]string, error) {
+	const (
+		unvisited = 0
+		visiting  = 1
+		visited   = 2
+	)
+	state := make(map[string]int, len(byID))
+	var order []string
+
+	var visit func(id string) error
+	visit = func(id string) error {
+		switch state[id] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("dependency cycle detected at step %q", id)
+		}
+		state[id] = visiting
+		for _, dep := range byID[id].DependsOn {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[id] = visited
+		order = append(order, id)
+		return nil
+	}
+
+	for id := range byID {
+		if err := visit(id); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// Run applies steps in dependency order, running independent steps
+// concurrently up to opts.Concurrency, and returns the first error
+// encountered. Steps already marked complete in State are skipped.
+func (r *Runner) Run(ctx context.Context) error {
+	total := len(r.order)
+	completed := 0
+	for _, id := range r.order {
+		done, err := r.opts.State.IsComplete(id)
+		if err != nil {
+			return fmt.Errorf("checking state for %q: %w", id, err)
+		}
+		if done {
+			completed++
+			r.markDone(id)
+		}
+	}
+	r.opts.OnProgress(completed, total, "resuming")
+
+	sem := make(chan struct{}, r.opts.Concurrency)
+
+	// finished[id] is closed once the step settles; errs[id] holds its
+	// result. Closing (rather than sending once) lets every dependent
+	// goroutine observe the same outcome.
+	finished := make(map[string]chan struct{}, len(r.order))
+	errs := make(map[string]error, len(r.order))
+	var errMu sync.Mutex
+	for _, id := range r.order {
+		finished[id] = make(chan struct{})
+	}
+
+	var wg sync.WaitGroup
+	for _, id := range r.order {
+		id := id
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(finished[id])
+			err := r.runOne(ctx, id, sem, finished, errs, &errMu)
+			errMu.Lock()
+			errs[id] = err
+			errMu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	for _, id := range r.order {
+		if err := errs[id]; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Runner) runOne(ctx context.Context, id string, sem chan struct{}, finished map[string]chan struct{}, errs map[string]error, errMu *sync.Mutex) error {
+	step := r.steps[id]
+
+	for _, dep := range step.DependsOn {
+		<-finished[dep]
+		errMu.Lock()
+		depErr := errs[dep]
+		errMu.Unlock()
+		if depErr != nil {
+			return fmt.Errorf("dependency %q of %q failed: %w", dep, id, depErr)
+		}
+	}
+
+	if r.isDone(id) {
+		return nil
+	}
+
+	sem <- struct{}{}
+	defer func() { <-sem }()
+
+	if err := r.apply(ctx, step); err != nil {
+		return fmt.Errorf("step %q: %w", id, err)
+	}
+	if err := r.opts.State.MarkComplete(id); err != nil {
+		return fmt.Errorf("recording completion of %q: %w", id, err)
+	}
+	r.markDone(id)
+	r.opts.OnProgress(r.countDone(), len(r.order), id)
+	return nil
+}
+
+func (r *Runner) isDone(id string) bool {
+	r.doneMu.Lock()
+	defer r.doneMu.Unlock()
+	return r.doneSet[id]
+}
+
+func (r *Runner) markDone(id string) {
+	r.doneMu.Lock()
+	defer r.doneMu.Unlock()
+	r.doneSet[id] = true
+}
+
+func (r *Runner) countDone() int {
+	r.doneMu.Lock()
+	defer r.doneMu.Unlock()
+	return len(r.doneSet)
+}
+
+// apply POSTs a step's payload to the RCRT API with an idempotency key
+// derived from the step ID, then waits on its health probe if it has one.
+func (r *Runner) apply(ctx context.Context, step Step) error {
+	body, err := json.Marshal(step.Payload)
+	if err != nil {
+		return fmt.Errorf("encoding payload: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/bootstrap/%ss", r.opts.BaseURL, step.Kind)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", idempotencyKey(step.ID))
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("applying step: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	if step.WaitFor != nil {
+		return r.awaitHealth(ctx, *step.WaitFor)
+	}
+	return nil
+}
+
+// awaitHealth polls a step's wait_for URL until it returns 2xx or timeout
+// elapses.
+func (r *Runner) awaitHealth(ctx context.Context, wf WaitFor) error {
+	timeout := time.Duration(wf.Timeout)
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, wf.URL, nil)
+		if err == nil {
+			if resp, err := r.client.Do(req); err == nil {
+				resp.Body.Close()
+				if resp.StatusCode < 300 {
+					return nil
+				}
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for %s", wf.URL)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// idempotencyKey derives a stable key from a step ID so re-applying the
+// same step (e.g. after a resumed run) is a no-op on the API side.
+func idempotencyKey(stepID string) string {
+	sum := sha256.Sum256([]byte(stepID))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,115 @@
+package bootstrap
+
+import "testing"
+
+func stepsByID(steps ...Step) map[string]Step {
+	byID := make(map[string]Step, len(steps))
+	for _, s := range steps {
+		byID[s.ID] = s
+	}
+	return byID
+}
+
+// indexOf returns the position of id in order, or -1 if absent.
+func indexOf(order []string, id string) int {
+	for i, o := range order {
+		if o == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestTopoSort_OrdersDependenciesFirst(t *testing.T) {
+	byID := stepsByID(
+		Step{ID: "a"},
+		Step{ID: "b", DependsOn: []string{"a"}},
+		Step{ID: "c", DependsOn: []string{"b"}},
+	)
+
+	order, err := topoSort(byID)
+	if err != nil {
+		t.Fatalf("topoSort() = %v, want nil", err)
+	}
+	if len(order) != 3 {
+		t.Fatalf("order = %v, want 3 entries", order)
+	}
+	if indexOf(order, "a") > indexOf(order, "b") {
+		t.Errorf("a must come before b: %v", order)
+	}
+	if indexOf(order, "b") > indexOf(order, "c") {
+		t.Errorf("b must come before c: %v", order)
+	}
+}
+
+func TestTopoSort_DiamondDependency(t *testing.T) {
+	byID := stepsByID(
+		Step{ID: "a"},
+		Step{ID: "b", DependsOn: []string{"a"}},
+		Step{ID: "c", DependsOn: []string{"a"}},
+		Step{ID: "d", DependsOn: []string{"b", "c"}},
+	)
+
+	order, err := topoSort(byID)
+	if err != nil {
+		t.Fatalf("topoSort() = %v, want nil", err)
+	}
+	if indexOf(order, "a") > indexOf(order, "b") || indexOf(order, "a") > indexOf(order, "c") {
+		t.Errorf("a must come before b and c: %v", order)
+	}
+	if indexOf(order, "b") > indexOf(order, "d") || indexOf(order, "c") > indexOf(order, "d") {
+		t.Errorf("b and c must come before d: %v", order)
+	}
+}
+
+func TestTopoSort_DetectsDirectCycle(t *testing.T) {
+	byID := stepsByID(
+		Step{ID: "a", DependsOn: []string{"b"}},
+		Step{ID: "b", DependsOn: []string{"a"}},
+	)
+
+	if _, err := topoSort(byID); err == nil {
+		t.Fatal("topoSort() = nil, want cycle error")
+	}
+}
+
+func TestTopoSort_DetectsSelfCycle(t *testing.T) {
+	byID := stepsByID(
+		Step{ID: "a", DependsOn: []string{"a"}},
+	)
+
+	if _, err := topoSort(byID); err == nil {
+		t.Fatal("topoSort() = nil, want cycle error")
+	}
+}
+
+func TestTopoSort_DetectsIndirectCycle(t *testing.T) {
+	byID := stepsByID(
+		Step{ID: "a", DependsOn: []string{"b"}},
+		Step{ID: "b", DependsOn: []string{"c"}},
+		Step{ID: "c", DependsOn: []string{"a"}},
+	)
+
+	if _, err := topoSort(byID); err == nil {
+		t.Fatal("topoSort() = nil, want cycle error")
+	}
+}
+
+func TestNewRunner_RejectsDuplicateStepID(t *testing.T) {
+	_, err := NewRunner([]Step{
+		{ID: "a"},
+		{ID: "a"},
+	}, Options{})
+	if err == nil {
+		t.Fatal("NewRunner() = nil, want duplicate id error")
+	}
+}
+
+func TestNewRunner_RejectsUnknownDependency(t *testing.T) {
+	_, err := NewRunner([]Step{
+		{ID: "a", DependsOn: []string{"missing"}},
+	}, Options{})
+	if err == nil {
+		t.Fatal("NewRunner() = nil, want unknown dependency error")
+	}
+}
@@ -0,0 +1,137 @@
+// Package bootstrap replaces the Node.js bootstrap.js shell-out with a
+// Go-native engine that applies declarative breadcrumbs against the RCRT
+// HTTP API, tracking per-step completion so partial failures resume
+// instead of restarting from scratch.
+package bootstrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kind is the category of work a Step performs.
+type Kind string
+
+const (
+	KindAgent  Kind = "agent"
+	KindTool   Kind = "tool"
+	KindConfig Kind = "config"
+)
+
+// WaitFor is an optional health probe a Step waits on after its payload is
+// applied, e.g. polling until a newly-created tool reports ready.
+type WaitFor struct {
+	URL     string   `yaml:"url" json:"url"`
+	Timeout Duration `yaml:"timeout" json:"timeout"`
+}
+
+// Duration wraps time.Duration so breadcrumb authors can write a duration
+// string ("30s") in both YAML and JSON breadcrumbs. A bare time.Duration
+// unmarshals a quoted duration string fine in YAML but fails under
+// encoding/json, which has no native duration support and would otherwise
+// require JSON breadcrumbs to spell timeouts out in nanoseconds.
+type Duration time.Duration
+
+// UnmarshalJSON accepts either a duration string ("30s") or a raw number
+// of nanoseconds.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch val := v.(type) {
+	case string:
+		parsed, err := time.ParseDuration(val)
+		if err != nil {
+			return fmt.Errorf("parsing duration %q: %w", val, err)
+		}
+		*d = Duration(parsed)
+	case float64:
+		*d = Duration(time.Duration(val))
+	default:
+		return fmt.Errorf("invalid duration value %v", v)
+	}
+	return nil
+}
+
+// UnmarshalYAML accepts either a duration string ("30s") or a raw number
+// of nanoseconds, matching UnmarshalJSON's behavior.
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	if node.ShortTag() == "!!int" {
+		var n int64
+		if err := node.Decode(&n); err != nil {
+			return fmt.Errorf("decoding duration: %w", err)
+		}
+		*d = Duration(n)
+		return nil
+	}
+
+	var s string
+	if err := node.Decode(&s); err != nil {
+		return fmt.Errorf("decoding duration: %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("parsing duration %q: %w", s, err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Step is one unit of bootstrap work read from a breadcrumb file.
+type Step struct {
+	ID        string                 `yaml:"id" json:"id"`
+	DependsOn []string               `yaml:"depends_on" json:"depends_on"`
+	Kind      Kind                   `yaml:"kind" json:"kind"`
+	Payload   map[string]interface{} `yaml:"payload" json:"payload"`
+	WaitFor   *WaitFor               `yaml:"wait_for,omitempty" json:"wait_for,omitempty"`
+}
+
+// LoadManifests reads every *.yaml, *.yml, and *.json breadcrumb in dir
+// and returns the combined, unordered step list; Runner is responsible
+// for topologically ordering them via DependsOn.
+func LoadManifests(dir string) ([]Step, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading breadcrumbs dir: %w", err)
+	}
+
+	var steps []Step
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		ext := filepath.Ext(entry.Name())
+		if ext != ".yaml" && ext != ".yml" && ext != ".json" {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+
+		var step Step
+		var unmarshal func([]byte, interface{}) error
+		if ext == ".json" {
+			unmarshal = json.Unmarshal
+		} else {
+			unmarshal = yaml.Unmarshal
+		}
+		if err := unmarshal(data, &step); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		if step.ID == "" {
+			return nil, fmt.Errorf("%s: step is missing an id", path)
+		}
+		steps = append(steps, step)
+	}
+
+	return steps, nil
+}
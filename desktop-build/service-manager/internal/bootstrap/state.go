@@ -0,0 +1,66 @@
+package bootstrap
+
+import (
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var stepsBucket = []byte("completed_steps")
+
+// StateStore records which steps have already completed, so a bootstrap
+// run interrupted partway through resumes instead of restarting from the
+// first step. It replaces the old `.bootstrapped` marker file.
+type StateStore struct {
+	db *bolt.DB
+}
+
+// OpenStateStore opens (creating if needed) the BoltDB file at path.
+func OpenStateStore(path string) (*StateStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("opening state db: %w", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(stepsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("initializing state db: %w", err)
+	}
+	return &StateStore{db: db}, nil
+}
+
+func (s *StateStore) Close() error {
+	return s.db.Close()
+}
+
+// IsComplete reports whether stepID has already run successfully.
+func (s *StateStore) IsComplete(stepID string) (bool, error) {
+	var done bool
+	err := s.db.View(func(tx *bolt.Tx) error {
+		done = tx.Bucket(stepsBucket).Get([]byte(stepID)) != nil
+		return nil
+	})
+	return done, err
+}
+
+// MarkComplete records that stepID ran successfully.
+func (s *StateStore) MarkComplete(stepID string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(stepsBucket).Put([]byte(stepID), []byte(time.Now().UTC().Format(time.RFC3339)))
+	})
+}
+
+// Reset clears all recorded completions, backing the --reset-bootstrap flag.
+func (s *StateStore) Reset() error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(stepsBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucket(stepsBucket)
+		return err
+	})
+}
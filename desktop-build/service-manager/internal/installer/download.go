@@ -0,0 +1,137 @@
+package installer
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// downloadResumable fetches url into destPath by way of destPath+".part",
+// resuming from the .part file's current size via an HTTP Range request if
+// a prior, interrupted attempt left one behind. onProgress is called as
+// bytes arrive.
+func downloadResumable(ctx context.Context, url, destPath string, onProgress ProgressFunc) error {
+	var resumeFrom int64
+	if info, err := os.Stat(destPath + ".part"); err == nil {
+		resumeFrom = info.Size()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if resumeFrom > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", resumeFrom))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("downloading %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		flags |= os.O_APPEND
+	case http.StatusOK:
+		// The server ignored our Range request and is sending the whole
+		// file from the start, so any stale .part from a prior attempt
+		// must not survive past however much this response actually
+		// writes — otherwise its leftover tail rides along into destPath.
+		resumeFrom = 0
+		flags |= os.O_TRUNC
+	default:
+		return fmt.Errorf("downloading %s: unexpected status %s", url, resp.Status)
+	}
+
+	partPath := destPath + ".part"
+	f, err := os.OpenFile(partPath, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", partPath, err)
+	}
+	defer f.Close()
+
+	total := resumeFrom + resp.ContentLength
+	done := resumeFrom
+	buf := make([]byte, 32*1024)
+	for {
+		n, readErr := resp.Body.Read(buf)
+		if n > 0 {
+			if _, err := f.Write(buf[:n]); err != nil {
+				return fmt.Errorf("writing %s: %w", partPath, err)
+			}
+			done += int64(n)
+			onProgress("downloading", done, total)
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return fmt.Errorf("downloading %s: %w", url, readErr)
+		}
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(partPath, destPath)
+}
+
+// fetchChecksum downloads a sha256sum-style manifest (lines of "<hex
+// digest>  <filename>", as Podman publishes alongside each release) from
+// manifestURL and returns the digest for filename. Pulling the digest from
+// the release's own signed manifest instead of hardcoding it means
+// verification doesn't go stale — or silently never pass — the moment
+// Podman ships a new patch release.
+func fetchChecksum(ctx context.Context, manifestURL, filename string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("downloading checksum manifest %s: %w", manifestURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("downloading checksum manifest %s: unexpected status %s", manifestURL, resp.Status)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == filename {
+			return fields[0], nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no checksum for %s in %s", filename, manifestURL)
+}
+
+// verifySHA256 reports an error if destPath's contents don't hash to want.
+func verifySHA256(destPath, want string) error {
+	f, err := os.Open(destPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	got := hex.EncodeToString(h.Sum(nil))
+	if got != want {
+		return fmt.Errorf("checksum mismatch: got %s, want %s", got, want)
+	}
+	return nil
+}
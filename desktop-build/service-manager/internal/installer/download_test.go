@@ -0,0 +1,89 @@
+package installer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifySHA256(t *testing.T) {
+	content := []byte("podman installer contents")
+	sum := sha256.Sum256(content)
+	want := hex.EncodeToString(sum[:])
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "installer.bin")
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatalf("writing test file: %v", err)
+	}
+
+	if err := verifySHA256(path, want); err != nil {
+		t.Errorf("verifySHA256() with matching digest = %v, want nil", err)
+	}
+
+	if err := verifySHA256(path, "0000000000000000000000000000000000000000000000000000000000000"); err == nil {
+		t.Error("verifySHA256() with mismatched digest = nil, want error")
+	}
+}
+
+func TestVerifySHA256_MissingFile(t *testing.T) {
+	if err := verifySHA256(filepath.Join(t.TempDir(), "does-not-exist"), "anything"); err == nil {
+		t.Error("verifySHA256() on a missing file = nil, want error")
+	}
+}
+
+func TestFetchChecksum_ParsesManifest(t *testing.T) {
+	manifest := "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa  podman-5.2.2-setup.exe\n" +
+		"bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb  podman-installer-macos-universal.pkg\n"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(manifest))
+	}))
+	defer srv.Close()
+
+	got, err := fetchChecksum(context.Background(), srv.URL, "podman-installer-macos-universal.pkg")
+	if err != nil {
+		t.Fatalf("fetchChecksum() = %v, want nil", err)
+	}
+	want := "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	if got != want {
+		t.Errorf("fetchChecksum() = %q, want %q", got, want)
+	}
+
+	if _, err := fetchChecksum(context.Background(), srv.URL, "not-in-manifest.pkg"); err == nil {
+		t.Error("fetchChecksum() for an unlisted file = nil, want error")
+	}
+}
+
+// TestDownloadResumable_TruncatesStalePartOn200 covers a server that ignores
+// the Range header and answers 200 to a resume attempt: the pre-existing
+// .part must not leave its longer tail behind in the final file.
+func TestDownloadResumable_TruncatesStalePartOn200(t *testing.T) {
+	dir := t.TempDir()
+	destPath := filepath.Join(dir, "installer.bin")
+	if err := os.WriteFile(destPath+".part", []byte("a much longer stale leftover"), 0644); err != nil {
+		t.Fatalf("seeding stale .part: %v", err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("short"))
+	}))
+	defer srv.Close()
+
+	if err := downloadResumable(context.Background(), srv.URL, destPath, func(string, int64, int64) {}); err != nil {
+		t.Fatalf("downloadResumable() = %v, want nil", err)
+	}
+
+	got, err := os.ReadFile(destPath)
+	if err != nil {
+		t.Fatalf("reading downloaded file: %v", err)
+	}
+	if string(got) != "short" {
+		t.Errorf("downloadResumable() wrote %q, want %q", got, "short")
+	}
+}
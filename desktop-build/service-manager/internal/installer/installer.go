@@ -0,0 +1,29 @@
+// Package installer provisions Podman (and, on Windows, WSL2) when
+// findPodman can't locate an existing install, instead of just logging
+// that the user may need to restart Windows.
+package installer
+
+import "context"
+
+// ProgressFunc reports download/install progress, e.g. to surface in the
+// tray tooltip as "RCRT - downloading (1048576/4194304)".
+type ProgressFunc func(stage string, done, total int64)
+
+// Installer is implemented once per platform in installer_windows.go,
+// installer_darwin.go, and installer_linux.go.
+type Installer interface {
+	// Detect reports whether Podman (and any platform prerequisite, like
+	// WSL2) is already present.
+	Detect(ctx context.Context) (bool, error)
+	// Install provisions whatever is missing. It must be safe to call
+	// again after a partial failure: an interrupted download resumes from
+	// its .part file rather than restarting, and a WSL2 or Podman already
+	// present is not installed again. A download that already completed
+	// is fetched again in full.
+	Install(ctx context.Context, onProgress ProgressFunc) error
+}
+
+// New returns the Installer for the current platform.
+func New() Installer {
+	return newPlatformInstaller()
+}
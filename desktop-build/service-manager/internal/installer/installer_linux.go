@@ -0,0 +1,82 @@
+//go:build linux
+
+package installer
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// distroCommands maps an /etc/os-release ID_LIKE/ID entry to the command
+// that installs Podman on that family, since Linux has no single
+// installable path the way Windows/macOS do.
+var distroCommands = map[string]string{
+	"debian": "sudo apt-get install -y podman",
+	"ubuntu": "sudo apt-get install -y podman",
+	"fedora": "sudo dnf install -y podman",
+	"rhel":   "sudo dnf install -y podman",
+	"centos": "sudo dnf install -y podman",
+	"arch":   "sudo pacman -S podman",
+}
+
+type linuxInstaller struct{}
+
+func newPlatformInstaller() Installer { return linuxInstaller{} }
+
+func (linuxInstaller) Detect(ctx context.Context) (bool, error) {
+	_, err := exec.LookPath("podman")
+	return err == nil, nil
+}
+
+// Install never installs anything on Linux: package managers need root
+// and vary by distro, so the safe, honest behavior is printing the exact
+// command for the detected distro and returning an error asking the user
+// to run it.
+func (linuxInstaller) Install(ctx context.Context, onProgress ProgressFunc) error {
+	ids, err := detectDistroIDs()
+	if err != nil {
+		return fmt.Errorf("detecting distro: %w", err)
+	}
+
+	for _, id := range ids {
+		if cmd, ok := distroCommands[id]; ok {
+			onProgress(cmd, 0, 1)
+			return fmt.Errorf("Podman is not installed. Run this to install it:\n\n  %s\n", cmd)
+		}
+	}
+
+	return fmt.Errorf("Podman is not installed and %s is not a recognized distro; install podman via your package manager", strings.Join(ids, ", "))
+}
+
+// detectDistroIDs reads ID and ID_LIKE from /etc/os-release, most
+// specific first.
+func detectDistroIDs() ([]string, error) {
+	f, err := os.Open("/etc/os-release")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var id string
+	var like []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "ID="):
+			id = strings.Trim(strings.TrimPrefix(line, "ID="), `"`)
+		case strings.HasPrefix(line, "ID_LIKE="):
+			like = strings.Fields(strings.Trim(strings.TrimPrefix(line, "ID_LIKE="), `"`))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	ids := append([]string{id}, like...)
+	return ids, nil
+}
@@ -0,0 +1,103 @@
+//go:build windows
+
+package installer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// podmanMirrorEnv lets operators point at an internal mirror instead of
+// the upstream GitHub release, without a code change.
+const podmanMirrorEnv = "RCRT_PODMAN_INSTALLER_URL"
+
+// defaultInstallerURL/checksumsURL pin the exact Podman release this build
+// is tested against; see fetchChecksum for why the digest itself isn't
+// pinned here too.
+const (
+	defaultInstallerURL = "https://github.com/containers/podman/releases/download/v5.2.2/podman-5.2.2-setup.exe"
+	checksumsURL        = "https://github.com/containers/podman/releases/download/v5.2.2/shasums256.txt"
+	installerFilename   = "podman-5.2.2-setup.exe"
+)
+
+type windowsInstaller struct{}
+
+func newPlatformInstaller() Installer { return windowsInstaller{} }
+
+func (windowsInstaller) Detect(ctx context.Context) (bool, error) {
+	wslOK := wslEnabled(ctx)
+	_, err := exec.LookPath("podman")
+	return wslOK && err == nil, nil
+}
+
+func (windowsInstaller) Install(ctx context.Context, onProgress ProgressFunc) error {
+	if !wslEnabled(ctx) {
+		onProgress("wsl", 0, 1)
+		if err := installWSL(ctx); err != nil {
+			return fmt.Errorf("installing WSL2: %w", err)
+		}
+		onProgress("wsl", 1, 1)
+	}
+
+	if _, err := exec.LookPath("podman"); err == nil {
+		return nil
+	}
+
+	url := defaultInstallerURL
+	if override := os.Getenv(podmanMirrorEnv); override != "" {
+		url = override
+	}
+
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return fmt.Errorf("resolving cache dir: %w", err)
+	}
+	destDir := filepath.Join(cacheDir, "rcrt", "installers")
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return fmt.Errorf("creating %s: %w", destDir, err)
+	}
+	destPath := filepath.Join(destDir, "podman-setup.exe")
+
+	if err := downloadResumable(ctx, url, destPath, onProgress); err != nil {
+		return err
+	}
+	want, err := fetchChecksum(ctx, checksumsURL, installerFilename)
+	if err != nil {
+		return fmt.Errorf("fetching podman installer checksum: %w", err)
+	}
+	if err := verifySHA256(destPath, want); err != nil {
+		return fmt.Errorf("verifying podman installer: %w", err)
+	}
+
+	onProgress("installing", 0, 1)
+	cmd := exec.CommandContext(ctx, destPath, "/quiet")
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("running podman installer: %w", err)
+	}
+	onProgress("installing", 1, 1)
+
+	return nil
+}
+
+// wslEnabled reports whether WSL2 is already enabled via `wsl --status`.
+func wslEnabled(ctx context.Context) bool {
+	out, err := exec.CommandContext(ctx, "wsl.exe", "--status").CombinedOutput()
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(out), "Default Version: 2")
+}
+
+// installWSL runs the WSL2 install under UAC elevation (wsl --install
+// itself needs admin rights), without pulling a Linux distribution since
+// Podman brings its own machine image.
+func installWSL(ctx context.Context) error {
+	cmd := exec.CommandContext(ctx, "powershell.exe",
+		"-NoProfile", "-Command",
+		"Start-Process wsl.exe -ArgumentList '--install','--no-distribution' -Verb RunAs -Wait")
+	return cmd.Run()
+}
@@ -0,0 +1,38 @@
+package machine
+
+import (
+	"errors"
+	"strings"
+)
+
+// errCorrupted is wrapped into the error returned by a provider's Start
+// when the machine needs Recreate rather than another Start attempt.
+var errCorrupted = errors.New("podman machine is corrupted")
+
+// knownTransientErrors are the machine start failures the tray previously
+// detected via ad-hoc substring matching in main.go. They all mean the same
+// thing: the machine is corrupted and needs Recreate, not a retry of Start.
+var knownTransientErrors = []string{
+	"ssh error",
+	"pipe instances are busy",
+	"not transition into running",
+}
+
+// isCorrupted reports whether output from `podman machine start` indicates
+// the machine needs to be recreated rather than simply retried.
+func isCorrupted(output string) bool {
+	lower := strings.ToLower(output)
+	for _, needle := range knownTransientErrors {
+		if strings.Contains(lower, needle) {
+			return true
+		}
+	}
+	return false
+}
+
+// isAlreadyRunning reports whether output from `podman machine start`
+// indicates the machine was already running, which is not an error.
+func isAlreadyRunning(output string) bool {
+	lower := strings.ToLower(output)
+	return strings.Contains(lower, "already running") || strings.Contains(lower, "already started")
+}
@@ -0,0 +1,46 @@
+package machine
+
+import "testing"
+
+func TestIsCorrupted(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   bool
+	}{
+		{"ssh error", "Error: ssh error: exit status 255", true},
+		{"busy pipe", "Error: pipe instances are busy", true},
+		{"failed transition", "machine did not transition into running state", true},
+		{"case insensitive", "SSH ERROR: connection refused", true},
+		{"unrelated failure", "Error: no such machine", false},
+		{"empty output", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isCorrupted(tt.output); got != tt.want {
+				t.Errorf("isCorrupted(%q) = %v, want %v", tt.output, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsAlreadyRunning(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   bool
+	}{
+		{"already running", "machine podman-machine-default is already running", true},
+		{"already started", "VM already started", true},
+		{"not running", "machine is stopped", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isAlreadyRunning(tt.output); got != tt.want {
+				t.Errorf("isAlreadyRunning(%q) = %v, want %v", tt.output, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+// Package machine models the Podman machine as a state machine so the tray
+// app can reason about lifecycle and recovery the same way on every
+// platform, instead of parsing `machine list` output inline.
+package machine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// State is a stage in the Podman machine lifecycle.
+type State int
+
+const (
+	NotInstalled State = iota
+	Initializing
+	Starting
+	Running
+	Degraded
+	Corrupted
+)
+
+func (s State) String() string {
+	switch s {
+	case NotInstalled:
+		return "NotInstalled"
+	case Initializing:
+		return "Initializing"
+	case Starting:
+		return "Starting"
+	case Running:
+		return "Running"
+	case Degraded:
+		return "Degraded"
+	case Corrupted:
+		return "Corrupted"
+	default:
+		return "Unknown"
+	}
+}
+
+// Health is the result of probing the machine's Podman socket directly,
+// rather than sleeping an arbitrary duration and hoping it's ready.
+type Health struct {
+	State     State
+	Reachable bool
+	Detail    string
+}
+
+// InitOptions configures a first-time machine Init.
+type InitOptions struct {
+	Rootful bool
+	Name    string
+}
+
+// Provider is implemented once per platform (WSL on Windows, QEMU/AppleHV
+// on macOS, native rootless on Linux). main.go selects the right one at
+// build time via the _windows.go/_darwin.go/_linux.go files in this
+// package.
+type Provider interface {
+	// Detect reports whether the Podman machine backend is installed and,
+	// if so, its version string.
+	Detect(ctx context.Context) (installed bool, version string, err error)
+	Init(ctx context.Context, opts InitOptions) error
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+	// Recreate tears down and reinitializes the machine with the same
+	// opts Ensure was called with, so a recovery cycle doesn't silently
+	// change how the machine was originally provisioned.
+	Recreate(ctx context.Context, opts InitOptions) error
+	// Probe connects to the podman socket to determine real health,
+	// rather than parsing `machine list` string output.
+	Probe(ctx context.Context) (Health, error)
+}
+
+// RetryConfig bounds the exponential backoff used by Ensure when recovering
+// from a Degraded or Corrupted machine.
+type RetryConfig struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryConfig matches the ~2 minute recreate window the tray already
+// warns users about, spread across a handful of backed-off attempts.
+var DefaultRetryConfig = RetryConfig{
+	MaxAttempts: 5,
+	BaseDelay:   2 * time.Second,
+	MaxDelay:    30 * time.Second,
+}
+
+// StatusFunc receives human-readable progress, e.g. to drive the tray's
+// "Status: Recovering (attempt N/M)" label.
+type StatusFunc func(status string)
+
+// Ensure brings the machine to Running, recovering from the ssh error /
+// pipe instances are busy / not transition into running states that
+// startPodmanServices used to detect via ad-hoc substring matching. It is
+// the single place that retries with backoff.
+func Ensure(ctx context.Context, p Provider, opts InitOptions, retry RetryConfig, onStatus StatusFunc) error {
+	if onStatus == nil {
+		onStatus = func(string) {}
+	}
+
+	installed, _, err := p.Detect(ctx)
+	if err != nil {
+		return fmt.Errorf("detecting podman machine: %w", err)
+	}
+	if !installed {
+		onStatus("Status: Initializing...")
+		if err := p.Init(ctx, opts); err != nil {
+			return fmt.Errorf("initializing podman machine: %w", err)
+		}
+	}
+
+	delay := retry.BaseDelay
+	for attempt := 1; attempt <= retry.MaxAttempts; attempt++ {
+		onStatus(fmt.Sprintf("Status: Starting... (attempt %d/%d)", attempt, retry.MaxAttempts))
+
+		startErr := p.Start(ctx)
+		if startErr != nil && !errors.Is(startErr, errCorrupted) {
+			return fmt.Errorf("starting podman machine: %w", startErr)
+		}
+
+		var health Health
+		if startErr == nil {
+			health, err = p.Probe(ctx)
+			if err == nil && health.State == Running && health.Reachable {
+				return nil
+			}
+		} else {
+			health = Health{State: Corrupted, Detail: startErr.Error()}
+		}
+
+		if attempt == retry.MaxAttempts {
+			return fmt.Errorf("podman machine did not reach Running after %d attempts: %s", attempt, health.Detail)
+		}
+
+		onStatus(fmt.Sprintf("Status: Recovering (attempt %d/%d)", attempt, retry.MaxAttempts))
+		if err := p.Recreate(ctx, opts); err != nil {
+			return fmt.Errorf("recreating podman machine: %w", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > retry.MaxDelay {
+			delay = retry.MaxDelay
+		}
+	}
+
+	return fmt.Errorf("podman machine did not reach Running")
+}
+
+// cliProvider drives a real Podman machine VM (WSL on Windows, QEMU/AppleHV
+// on macOS) through the `podman machine` CLI. Detect/Init/Start/Stop/Recreate
+// are identical across those backends; only the socket a platform probes
+// differs, so platform files embed cliProvider and supply just Probe.
+type cliProvider struct {
+	podmanExe string
+	name      string
+}
+
+// Detect reports whether the named machine VM already exists, mirroring the
+// baseline's `podman machine list` check — unlike `podman version`, which
+// only proves the CLI runs and says nothing about whether `machine init` has
+// ever been run, so relying on it would skip Init on every first run.
+func (p *cliProvider) Detect(ctx context.Context) (bool, string, error) {
+	out, err := exec.CommandContext(ctx, p.podmanExe, "machine", "list", "--format", "{{.Name}}").Output()
+	if err != nil {
+		return false, "", nil
+	}
+	for _, name := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if strings.TrimSpace(name) != p.name {
+			continue
+		}
+		version, err := exec.CommandContext(ctx, p.podmanExe, "version", "--format", "{{.Client.Version}}").Output()
+		if err != nil {
+			return true, "", nil
+		}
+		return true, strings.TrimSpace(string(version)), nil
+	}
+	return false, "", nil
+}
+
+func (p *cliProvider) Init(ctx context.Context, opts InitOptions) error {
+	name := opts.Name
+	if name == "" {
+		name = p.name
+	}
+	args := []string{"machine", "init", "--now"}
+	if opts.Rootful {
+		args = append(args, "--rootful")
+	}
+	args = append(args, name)
+	return exec.CommandContext(ctx, p.podmanExe, args...).Run()
+}
+
+func (p *cliProvider) Start(ctx context.Context) error {
+	out, err := exec.CommandContext(ctx, p.podmanExe, "machine", "start", p.name).CombinedOutput()
+	if err == nil {
+		return nil
+	}
+	if isAlreadyRunning(string(out)) {
+		return nil
+	}
+	if isCorrupted(string(out)) {
+		return fmt.Errorf("%w: %s", errCorrupted, strings.TrimSpace(string(out)))
+	}
+	return fmt.Errorf("podman machine start: %w: %s", err, strings.TrimSpace(string(out)))
+}
+
+func (p *cliProvider) Stop(ctx context.Context) error {
+	return exec.CommandContext(ctx, p.podmanExe, "machine", "stop", p.name).Run()
+}
+
+func (p *cliProvider) Recreate(ctx context.Context, opts InitOptions) error {
+	exec.CommandContext(ctx, p.podmanExe, "machine", "stop", "-f", p.name).Run()
+	exec.CommandContext(ctx, p.podmanExe, "machine", "rm", "-f", p.name).Run()
+	opts.Name = p.name
+	return p.Init(ctx, opts)
+}
@@ -0,0 +1,36 @@
+//go:build darwin
+
+package machine
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// qemuProvider drives the Podman machine via QEMU, or AppleHV on supported
+// hosts, mirroring upstream Podman's pkg/machine/qemu backend.
+type qemuProvider struct {
+	cliProvider
+}
+
+// New returns the Provider for the current platform.
+func New(podmanExe string) Provider {
+	return &qemuProvider{cliProvider{podmanExe: podmanExe, name: "podman-machine-default"}}
+}
+
+func (p *qemuProvider) Probe(ctx context.Context) (Health, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return Health{State: Degraded, Detail: err.Error()}, err
+	}
+	sock := filepath.Join(home, ".local", "share", "containers", "podman", "machine", "podman.sock")
+	conn, err := net.DialTimeout("unix", sock, 3*time.Second)
+	if err != nil {
+		return Health{State: Degraded, Reachable: false, Detail: err.Error()}, nil
+	}
+	conn.Close()
+	return Health{State: Running, Reachable: true}, nil
+}
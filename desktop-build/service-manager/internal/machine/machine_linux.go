@@ -0,0 +1,53 @@
+//go:build linux
+
+package machine
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// nativeProvider drives Podman rootless directly on the host; Linux has no
+// machine VM, so Init/Start/Stop/Recreate are no-ops and Probe dials the
+// real rootless socket.
+type nativeProvider struct {
+	podmanExe string
+}
+
+// New returns the Provider for the current platform.
+func New(podmanExe string) Provider {
+	return &nativeProvider{podmanExe: podmanExe}
+}
+
+func (p *nativeProvider) Detect(ctx context.Context) (bool, string, error) {
+	out, err := exec.CommandContext(ctx, p.podmanExe, "version", "--format", "{{.Client.Version}}").Output()
+	if err != nil {
+		return false, "", nil
+	}
+	return true, strings.TrimSpace(string(out)), nil
+}
+
+func (p *nativeProvider) Init(ctx context.Context, opts InitOptions) error     { return nil }
+func (p *nativeProvider) Start(ctx context.Context) error                     { return nil }
+func (p *nativeProvider) Stop(ctx context.Context) error                      { return nil }
+func (p *nativeProvider) Recreate(ctx context.Context, opts InitOptions) error { return nil }
+
+func (p *nativeProvider) Probe(ctx context.Context) (Health, error) {
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		return Health{State: Degraded}, fmt.Errorf("XDG_RUNTIME_DIR is not set")
+	}
+	sock := filepath.Join(runtimeDir, "podman", "podman.sock")
+	conn, err := net.DialTimeout("unix", sock, 3*time.Second)
+	if err != nil {
+		return Health{State: Degraded, Reachable: false, Detail: err.Error()}, nil
+	}
+	conn.Close()
+	return Health{State: Running, Reachable: true}, nil
+}
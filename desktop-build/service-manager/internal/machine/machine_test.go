@@ -0,0 +1,141 @@
+package machine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// fakeProvider drives Ensure's state machine through scripted responses
+// instead of a real Podman machine.
+type fakeProvider struct {
+	installed bool
+
+	startErrs   []error // consumed in order, one per Start call
+	probeHealth Health
+	probeErr    error
+
+	recreateCalls int
+	recreateErr   error
+}
+
+func (p *fakeProvider) Detect(ctx context.Context) (bool, string, error) {
+	return p.installed, "5.2.2", nil
+}
+
+func (p *fakeProvider) Init(ctx context.Context, opts InitOptions) error {
+	p.installed = true
+	return nil
+}
+
+func (p *fakeProvider) Start(ctx context.Context) error {
+	if len(p.startErrs) == 0 {
+		return nil
+	}
+	err := p.startErrs[0]
+	p.startErrs = p.startErrs[1:]
+	return err
+}
+
+func (p *fakeProvider) Stop(ctx context.Context) error { return nil }
+
+func (p *fakeProvider) Recreate(ctx context.Context, opts InitOptions) error {
+	p.recreateCalls++
+	return p.recreateErr
+}
+
+func (p *fakeProvider) Probe(ctx context.Context) (Health, error) {
+	return p.probeHealth, p.probeErr
+}
+
+// fastRetry keeps the backoff loop from slowing tests down.
+var fastRetry = RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+func TestEnsure_HealthyOnFirstStart(t *testing.T) {
+	p := &fakeProvider{installed: true, probeHealth: Health{State: Running, Reachable: true}}
+
+	if err := Ensure(context.Background(), p, InitOptions{}, fastRetry, nil); err != nil {
+		t.Fatalf("Ensure() = %v, want nil", err)
+	}
+	if p.recreateCalls != 0 {
+		t.Errorf("recreateCalls = %d, want 0", p.recreateCalls)
+	}
+}
+
+func TestEnsure_InitsWhenNotInstalled(t *testing.T) {
+	p := &fakeProvider{installed: false, probeHealth: Health{State: Running, Reachable: true}}
+
+	if err := Ensure(context.Background(), p, InitOptions{}, fastRetry, nil); err != nil {
+		t.Fatalf("Ensure() = %v, want nil", err)
+	}
+	if !p.installed {
+		t.Error("expected Init to install the machine")
+	}
+}
+
+func TestEnsure_RecreatesOnCorruptedStart(t *testing.T) {
+	p := &fakeProvider{
+		installed:   true,
+		startErrs:   []error{fmt.Errorf("wrap: %w", errCorrupted)},
+		probeHealth: Health{State: Running, Reachable: true},
+	}
+
+	if err := Ensure(context.Background(), p, InitOptions{}, fastRetry, nil); err != nil {
+		t.Fatalf("Ensure() = %v, want nil", err)
+	}
+	if p.recreateCalls != 1 {
+		t.Errorf("recreateCalls = %d, want 1", p.recreateCalls)
+	}
+}
+
+func TestEnsure_RecreatesOnDegradedProbe(t *testing.T) {
+	// Reports Degraded until Recreate has run once, then Running,
+	// simulating a successful recovery.
+	fp := &flippingProvider{fakeProvider: fakeProvider{installed: true, probeHealth: Health{State: Degraded, Reachable: false}}}
+
+	if err := Ensure(context.Background(), fp, InitOptions{}, fastRetry, nil); err != nil {
+		t.Fatalf("Ensure() = %v, want nil", err)
+	}
+	if fp.recreateCalls != 1 {
+		t.Errorf("recreateCalls = %d, want 1", fp.recreateCalls)
+	}
+}
+
+// flippingProvider wraps fakeProvider so Recreate flips the probed health
+// from Degraded to Running, simulating a successful recovery.
+type flippingProvider struct {
+	fakeProvider
+}
+
+func (p *flippingProvider) Recreate(ctx context.Context, opts InitOptions) error {
+	p.recreateCalls++
+	p.probeHealth = Health{State: Running, Reachable: true}
+	return nil
+}
+
+func TestEnsure_ReturnsImmediatelyOnNonCorruptedStartError(t *testing.T) {
+	wantErr := errors.New("permission denied")
+	p := &fakeProvider{installed: true, startErrs: []error{wantErr}}
+
+	err := Ensure(context.Background(), p, InitOptions{}, fastRetry, nil)
+	if err == nil {
+		t.Fatal("Ensure() = nil, want error")
+	}
+	if p.recreateCalls != 0 {
+		t.Errorf("recreateCalls = %d, want 0 (should not recover from a non-corrupted error)", p.recreateCalls)
+	}
+}
+
+func TestEnsure_FailsAfterMaxAttempts(t *testing.T) {
+	p := &fakeProvider{installed: true, probeHealth: Health{State: Degraded, Reachable: false}}
+
+	err := Ensure(context.Background(), p, InitOptions{}, fastRetry, nil)
+	if err == nil {
+		t.Fatal("Ensure() = nil, want error")
+	}
+	if p.recreateCalls != fastRetry.MaxAttempts-1 {
+		t.Errorf("recreateCalls = %d, want %d", p.recreateCalls, fastRetry.MaxAttempts-1)
+	}
+}
@@ -0,0 +1,39 @@
+//go:build windows
+
+package machine
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/Microsoft/go-winio"
+)
+
+// wslProvider drives the Podman machine via WSL2, mirroring how upstream
+// Podman's pkg/machine/wsl manages the Windows backend.
+type wslProvider struct {
+	cliProvider
+}
+
+// New returns the Provider for the current platform.
+func New(podmanExe string) Provider {
+	return &wslProvider{cliProvider{podmanExe: podmanExe, name: "podman-machine-default"}}
+}
+
+func (p *wslProvider) Probe(ctx context.Context) (Health, error) {
+	sockPath, err := exec.CommandContext(ctx, p.podmanExe, "machine", "inspect", p.name, "--format", "{{.ConnectionInfo.PodmanPipe.Path}}").Output()
+	if err != nil {
+		return Health{State: Degraded, Detail: err.Error()}, err
+	}
+	path := strings.TrimSpace(string(sockPath))
+	dialCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	conn, err := winio.DialPipeContext(dialCtx, path)
+	if err != nil {
+		return Health{State: Degraded, Reachable: false, Detail: err.Error()}, nil
+	}
+	conn.Close()
+	return Health{State: Running, Reachable: true}, nil
+}
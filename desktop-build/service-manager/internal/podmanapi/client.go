@@ -0,0 +1,81 @@
+// Package podmanapi talks to the Podman REST API over the machine's
+// SSH-forwarded UNIX socket (or, on Windows, the WSL-forwarded named pipe)
+// instead of shelling out to `podman compose` and parsing its output.
+package podmanapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+const apiVersion = "v4.0.0"
+
+// Client is a thin wrapper around the Podman libpod REST API.
+type Client struct {
+	http *http.Client
+	// stream backs long-lived requests (EventsStream, LogsStream). It has
+	// no Timeout: http.Client.Timeout bounds the whole request including
+	// reading the body, which would force-close a streaming GET after
+	// Timeout elapses regardless of ctx. Callers' ctx already bounds these.
+	stream *http.Client
+}
+
+// NewClient returns a Client that dials the platform's Podman socket for
+// each new connection; it does not connect up front, so an unreachable
+// socket surfaces as an error from the first request. The dial target is
+// resolved by dialSocket, implemented per-platform in
+// socket_windows.go/socket_darwin.go/socket_linux.go.
+func NewClient() (*Client, error) {
+	dial := func(ctx context.Context, _, _ string) (net.Conn, error) {
+		return dialSocket(ctx)
+	}
+	transport := &http.Transport{DialContext: dial}
+	return &Client{
+		http:   &http.Client{Transport: transport, Timeout: 30 * time.Second},
+		stream: &http.Client{Transport: transport},
+	}, nil
+}
+
+// get issues a GET against the libpod API and decodes the JSON body into v.
+func (c *Client) get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://podman/"+apiVersion+"/libpod"+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("podman api %s: %s: %s", path, resp.Status, string(body))
+	}
+	if v == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// post issues a POST against the libpod API, discarding the response body.
+func (c *Client) post(ctx context.Context, path string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://podman/"+apiVersion+"/libpod"+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("podman api %s: %s: %s", path, resp.Status, string(body))
+	}
+	return nil
+}
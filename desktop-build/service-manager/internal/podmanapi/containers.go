@@ -0,0 +1,28 @@
+package podmanapi
+
+import "context"
+
+// ContainersList returns every container the Podman service knows about,
+// running or not; the list is not filtered to the compose project.
+func (c *Client) ContainersList(ctx context.Context) ([]Container, error) {
+	var containers []Container
+	if err := c.get(ctx, "/containers/json?all=true", &containers); err != nil {
+		return nil, err
+	}
+	return containers, nil
+}
+
+// ContainerStart starts a container by ID or name.
+func (c *Client) ContainerStart(ctx context.Context, id string) error {
+	return c.post(ctx, "/containers/"+id+"/start")
+}
+
+// ContainerStop stops a container by ID or name.
+func (c *Client) ContainerStop(ctx context.Context, id string) error {
+	return c.post(ctx, "/containers/"+id+"/stop")
+}
+
+// ContainerRestart restarts a container by ID or name.
+func (c *Client) ContainerRestart(ctx context.Context, id string) error {
+	return c.post(ctx, "/containers/"+id+"/restart")
+}
@@ -0,0 +1,50 @@
+package podmanapi
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// EventsStream follows the libpod event stream so callers can react to a
+// container's real "die"/"start" events instead of sleeping a fixed
+// duration and hoping things have settled. The channel is closed when ctx
+// is cancelled or the stream ends.
+func (c *Client) EventsStream(ctx context.Context) <-chan Event {
+	events := make(chan Event)
+
+	go func() {
+		defer close(events)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://podman/"+apiVersion+"/libpod/events?stream=true", nil)
+		if err != nil {
+			log.Printf("podmanapi: building events request: %v", err)
+			return
+		}
+		resp, err := c.stream.Do(req)
+		if err != nil {
+			log.Printf("podmanapi: connecting to events stream: %v", err)
+			return
+		}
+		defer resp.Body.Close()
+
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var ev Event
+			if err := dec.Decode(&ev); err != nil {
+				if ctx.Err() == nil {
+					log.Printf("podmanapi: events stream ended: %v", err)
+				}
+				return
+			}
+			select {
+			case events <- ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events
+}
@@ -0,0 +1,29 @@
+package podmanapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// LogsStream opens a live-tailing reader over a container's stdout/stderr,
+// so "View Logs" can show a live window instead of starting a detached
+// `podman compose logs` process whose output is lost.
+func (c *Client) LogsStream(ctx context.Context, id string) (io.ReadCloser, error) {
+	url := fmt.Sprintf("http://podman/%s/libpod/containers/%s/logs?follow=true&stdout=true&stderr=true&tail=200", apiVersion, id)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.stream.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("podman api logs %s: %s: %s", id, resp.Status, string(body))
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,22 @@
+//go:build darwin
+
+package podmanapi
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+)
+
+// dialSocket connects to the Podman API over the UNIX socket the macOS
+// Podman machine exposes under the user's home directory.
+func dialSocket(ctx context.Context) (net.Conn, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	sock := filepath.Join(home, ".local", "share", "containers", "podman", "machine", "podman.sock")
+	var d net.Dialer
+	return d.DialContext(ctx, "unix", sock)
+}
@@ -0,0 +1,23 @@
+//go:build linux
+
+package podmanapi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+)
+
+// dialSocket connects to the Podman API over the rootless UNIX socket
+// under $XDG_RUNTIME_DIR.
+func dialSocket(ctx context.Context) (net.Conn, error) {
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		return nil, fmt.Errorf("XDG_RUNTIME_DIR is not set")
+	}
+	sock := filepath.Join(runtimeDir, "podman", "podman.sock")
+	var d net.Dialer
+	return d.DialContext(ctx, "unix", sock)
+}
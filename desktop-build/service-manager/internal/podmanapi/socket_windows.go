@@ -0,0 +1,24 @@
+//go:build windows
+
+package podmanapi
+
+import (
+	"context"
+	"net"
+	"os/exec"
+	"strings"
+
+	"github.com/Microsoft/go-winio"
+)
+
+// dialSocket connects to the Podman API over the WSL-forwarded named pipe
+// (the podman.exe client dials the same pipe under the hood). The stdlib
+// net package has no "npipe" network type, so this goes through go-winio.
+func dialSocket(ctx context.Context) (net.Conn, error) {
+	out, err := exec.CommandContext(ctx, "podman", "machine", "inspect", "--format", "{{.ConnectionInfo.PodmanPipe.Path}}").Output()
+	if err != nil {
+		return nil, err
+	}
+	path := strings.TrimSpace(string(out))
+	return winio.DialPipeContext(ctx, path)
+}
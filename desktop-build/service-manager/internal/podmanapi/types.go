@@ -0,0 +1,22 @@
+package podmanapi
+
+// Container is the subset of libpod's container inspect fields the tray
+// needs to render status.
+type Container struct {
+	ID     string   `json:"Id"`
+	Names  []string `json:"Names"`
+	Image  string   `json:"Image"`
+	State  string   `json:"State"`
+	Status string   `json:"Status"`
+}
+
+// Event is one entry from the libpod event stream, e.g. a container "die"
+// or "start" event used to replace fixed Sleep() waits with real signals.
+type Event struct {
+	Type   string `json:"Type"`
+	Status string `json:"Status"`
+	Actor  struct {
+		ID         string            `json:"ID"`
+		Attributes map[string]string `json:"Attributes"`
+	} `json:"Actor"`
+}
@@ -0,0 +1,43 @@
+package secrets
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileFallbackStore writes the secret to a file on disk with restrictive
+// permissions. It exists only for platforms or environments where no
+// OS keystore is reachable (e.g. a headless CI box) and must be opted
+// into explicitly: New() returns it only when RCRT_SECRETS_FALLBACK_DIR
+// is set.
+type FileFallbackStore struct {
+	// Dir is the directory secrets are written under, e.g. a per-user
+	// config directory outside of the compose project.
+	Dir string
+}
+
+func (s FileFallbackStore) Seal(plaintext []byte) (string, error) {
+	if err := os.MkdirAll(s.Dir, 0700); err != nil {
+		return "", fmt.Errorf("creating secrets dir: %w", err)
+	}
+	path := filepath.Join(s.Dir, refLabel)
+	if err := os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(plaintext)), 0600); err != nil {
+		return "", fmt.Errorf("writing sealed secret: %w", err)
+	}
+	return "file:" + path, nil
+}
+
+func (s FileFallbackStore) Unseal(ref string) ([]byte, error) {
+	path, ok := strings.CutPrefix(ref, "file:")
+	if !ok {
+		return nil, ErrNotSealed
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading sealed secret: %w", err)
+	}
+	return base64.StdEncoding.DecodeString(string(data))
+}
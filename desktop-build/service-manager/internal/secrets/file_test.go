@@ -0,0 +1,46 @@
+package secrets
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFileFallbackStore_SealUnsealRoundTrip(t *testing.T) {
+	store := FileFallbackStore{Dir: t.TempDir()}
+	plaintext := []byte("super-secret-kek-material")
+
+	ref, err := store.Seal(plaintext)
+	if err != nil {
+		t.Fatalf("Seal() = %v, want nil", err)
+	}
+
+	got, err := store.Unseal(ref)
+	if err != nil {
+		t.Fatalf("Unseal() = %v, want nil", err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Errorf("Unseal() = %q, want %q", got, plaintext)
+	}
+}
+
+func TestFileFallbackStore_UnsealRejectsForeignRef(t *testing.T) {
+	store := FileFallbackStore{Dir: t.TempDir()}
+
+	if _, err := store.Unseal("keychain:rcrt-kek"); err != ErrNotSealed {
+		t.Errorf("Unseal() = %v, want ErrNotSealed", err)
+	}
+}
+
+func TestNew_HonorsFallbackDirEnv(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(fileFallbackDirEnv, dir)
+
+	store := New()
+	fallback, ok := store.(FileFallbackStore)
+	if !ok {
+		t.Fatalf("New() = %T, want FileFallbackStore", store)
+	}
+	if fallback.Dir != dir {
+		t.Errorf("Dir = %q, want %q", fallback.Dir, dir)
+	}
+}
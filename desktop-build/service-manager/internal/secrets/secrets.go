@@ -0,0 +1,48 @@
+// Package secrets seals the local KEK with the OS-native keystore instead
+// of leaving it in plaintext next to the compose file, and unseals it again
+// so the caller can inject it into the compose child process's environment.
+package secrets
+
+import (
+	"fmt"
+	"os"
+)
+
+// refLabel is the key name the Keychain, Secret Service and file stores
+// seal the KEK under; the DPAPI store carries the sealed blob in the
+// reference itself instead.
+const refLabel = "rcrt-kek"
+
+// fileFallbackDirEnv opts into FileFallbackStore in place of the OS-native
+// store, naming the directory secrets are written under. It exists only for
+// environments with no reachable OS keystore (e.g. a headless CI box or a
+// container); it must be set explicitly and is never chosen automatically.
+const fileFallbackDirEnv = "RCRT_SECRETS_FALLBACK_DIR"
+
+// KeyStore seals and unseals small secrets (here, the raw KEK bytes)
+// using whatever the OS provides: DPAPI on Windows, Keychain on macOS,
+// Secret Service on Linux.
+type KeyStore interface {
+	// Seal stores plaintext under the store's native mechanism and
+	// returns an opaque reference safe to write into .env.
+	Seal(plaintext []byte) (ref string, err error)
+	// Unseal resolves a reference previously returned by Seal back to
+	// the original plaintext.
+	Unseal(ref string) (plaintext []byte, err error)
+}
+
+// New returns the OS-native KeyStore for the current platform, implemented
+// per-platform in store_windows.go/store_darwin.go/store_linux.go. If
+// RCRT_SECRETS_FALLBACK_DIR is set, it returns a FileFallbackStore writing
+// under that directory instead — an explicit opt-in for environments with
+// no reachable OS keystore.
+func New() KeyStore {
+	if dir := os.Getenv(fileFallbackDirEnv); dir != "" {
+		return FileFallbackStore{Dir: dir}
+	}
+	return newPlatformStore()
+}
+
+// ErrNotSealed is returned by Unseal when ref isn't a reference this store
+// recognizes (e.g. it belongs to a different prefix).
+var ErrNotSealed = fmt.Errorf("secrets: reference not recognized by this store")
@@ -0,0 +1,82 @@
+//go:build darwin
+
+package secrets
+
+/*
+#cgo LDFLAGS: -framework Security -framework CoreFoundation
+
+#include <Security/Security.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// KeychainStore seals secrets in the macOS login Keychain directly through
+// the Security framework's Keychain Services API, not the `security` CLI:
+// shelling out and passing the plaintext KEK as `-w <secret>` leaves it
+// visible to any local user via `ps`/`/proc` for the life of the child
+// process — exactly the leak this feature exists to close.
+type KeychainStore struct {
+	Service string
+}
+
+func newPlatformStore() KeyStore { return KeychainStore{Service: "io.rcrt." + refLabel} }
+
+func (s KeychainStore) Seal(plaintext []byte) (string, error) {
+	cService := C.CString(s.Service)
+	defer C.free(unsafe.Pointer(cService))
+	cAccount := C.CString(refLabel)
+	defer C.free(unsafe.Pointer(cAccount))
+
+	var data unsafe.Pointer
+	if len(plaintext) > 0 {
+		data = unsafe.Pointer(&plaintext[0])
+	}
+
+	var item C.SecKeychainItemRef
+	status := C.SecKeychainFindGenericPassword(nil,
+		C.UInt32(len(s.Service)), cService,
+		C.UInt32(len(refLabel)), cAccount,
+		nil, nil, &item)
+	if status == C.errSecSuccess {
+		defer C.CFRelease(C.CFTypeRef(item))
+		status = C.SecKeychainItemModifyAttributesAndData(item, nil, C.UInt32(len(plaintext)), data)
+	} else {
+		status = C.SecKeychainAddGenericPassword(nil,
+			C.UInt32(len(s.Service)), cService,
+			C.UInt32(len(refLabel)), cAccount,
+			C.UInt32(len(plaintext)), data, nil)
+	}
+	if status != C.errSecSuccess {
+		return "", fmt.Errorf("keychain: OSStatus %d", status)
+	}
+	return "keychain:" + s.Service, nil
+}
+
+func (s KeychainStore) Unseal(ref string) ([]byte, error) {
+	service, ok := strings.CutPrefix(ref, "keychain:")
+	if !ok {
+		return nil, ErrNotSealed
+	}
+	cService := C.CString(service)
+	defer C.free(unsafe.Pointer(cService))
+	cAccount := C.CString(refLabel)
+	defer C.free(unsafe.Pointer(cAccount))
+
+	var length C.UInt32
+	var data unsafe.Pointer
+	status := C.SecKeychainFindGenericPassword(nil,
+		C.UInt32(len(service)), cService,
+		C.UInt32(len(refLabel)), cAccount,
+		&length, &data, nil)
+	if status != C.errSecSuccess {
+		return nil, fmt.Errorf("keychain: OSStatus %d", status)
+	}
+	defer C.SecKeychainItemFreeContent(nil, data)
+	return C.GoBytes(data, C.int(length)), nil
+}
@@ -0,0 +1,57 @@
+//go:build windows
+
+package secrets
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// DPAPIStore seals secrets with the Windows Data Protection API, scoped to
+// the current user, so the KEK is unreadable outside this Windows account.
+type DPAPIStore struct{}
+
+func newPlatformStore() KeyStore { return DPAPIStore{} }
+
+func (DPAPIStore) Seal(plaintext []byte) (string, error) {
+	if len(plaintext) == 0 {
+		return "", fmt.Errorf("sealing empty key")
+	}
+	in := windows.DataBlob{Size: uint32(len(plaintext)), Data: &plaintext[0]}
+	var out windows.DataBlob
+	if err := windows.CryptProtectData(&in, nil, nil, 0, nil, windows.CRYPTPROTECT_UI_FORBIDDEN, &out); err != nil {
+		return "", fmt.Errorf("CryptProtectData: %w", err)
+	}
+	defer windows.LocalFree(windows.Handle(uintptr(unsafe.Pointer(out.Data))))
+
+	sealed := unsafe.Slice(out.Data, out.Size)
+	return "dpapi:" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (DPAPIStore) Unseal(ref string) ([]byte, error) {
+	encoded, ok := strings.CutPrefix(ref, "dpapi:")
+	if !ok {
+		return nil, ErrNotSealed
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decoding sealed blob: %w", err)
+	}
+	if len(sealed) == 0 {
+		return nil, fmt.Errorf("unsealing empty blob")
+	}
+	in := windows.DataBlob{Size: uint32(len(sealed)), Data: &sealed[0]}
+	var out windows.DataBlob
+	if err := windows.CryptUnprotectData(&in, nil, nil, 0, nil, 0, &out); err != nil {
+		return nil, fmt.Errorf("CryptUnprotectData: %w", err)
+	}
+	defer windows.LocalFree(windows.Handle(uintptr(unsafe.Pointer(out.Data))))
+
+	plaintext := make([]byte, out.Size)
+	copy(plaintext, unsafe.Slice(out.Data, out.Size))
+	return plaintext, nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"runtime"
+	"sync"
+)
+
+// tailLogsFlag is the hidden CLI flag the tray re-execs itself with to
+// stream container logs into its own terminal window, so "View Logs"
+// opens a live tail instead of a detached `podman compose logs` process
+// whose output is lost.
+const tailLogsFlag = "-tail-logs"
+
+// openLogsWindow spawns a terminal running this same executable in log
+// tailing mode. With no containerIDs it tails every container Podman
+// lists, which covers what `podman compose logs` showed before.
+func openLogsWindow(containerIDs ...string) error {
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating executable: %w", err)
+	}
+
+	args := append([]string{tailLogsFlag}, containerIDs...)
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		cmd = exec.Command("cmd", "/c", "start", "RCRT Logs", self, args[0])
+		cmd.Args = append(cmd.Args, args[1:]...)
+	case "darwin":
+		cmd = exec.Command("open", "-a", "Terminal", self, "--args")
+		cmd.Args = append(cmd.Args, args...)
+	default:
+		cmd = exec.Command("x-terminal-emulator", "-e", self)
+		cmd.Args = append(cmd.Args, args...)
+	}
+	return cmd.Start()
+}
+
+// runTailLogs implements the hidden tailing mode. With no containerIDs it
+// discovers and tails every container Podman lists concurrently,
+// prefixing each line with the container's name. It blocks until every
+// stream ends or the process is killed.
+func runTailLogs(containerIDs []string) {
+	client, err := newPodmanClient()
+	if err != nil {
+		log.Fatalf("connecting to Podman API: %v", err)
+	}
+	ctx := context.Background()
+
+	type target struct{ id, name string }
+	var targets []target
+
+	if len(containerIDs) > 0 {
+		for _, id := range containerIDs {
+			targets = append(targets, target{id: id, name: id})
+		}
+	} else {
+		containers, err := client.ContainersList(ctx)
+		if err != nil {
+			log.Fatalf("listing containers: %v", err)
+		}
+		for _, c := range containers {
+			name := c.ID
+			if len(c.Names) > 0 {
+				name = c.Names[0]
+			}
+			targets = append(targets, target{id: c.ID, name: name})
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, t := range targets {
+		t := t
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stream, err := client.LogsStream(ctx, t.id)
+			if err != nil {
+				log.Printf("streaming logs for %s: %v", t.name, err)
+				return
+			}
+			defer stream.Close()
+			io.Copy(&prefixedWriter{name: t.name, out: os.Stdout}, stream)
+		}()
+	}
+	wg.Wait()
+}
+
+// prefixedWriter writes each chunk it receives with a "[name] " prefix, so
+// interleaved output from multiple containers stays attributable.
+type prefixedWriter struct {
+	name string
+	out  io.Writer
+}
+
+func (w *prefixedWriter) Write(p []byte) (int, error) {
+	if _, err := fmt.Fprintf(w.out, "[%s] ", w.name); err != nil {
+		return 0, err
+	}
+	return w.out.Write(p)
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -12,28 +14,44 @@ import (
 
 	"fyne.io/systray"
 	"github.com/skratchdot/open-golang/open"
+
+	"rcrt/internal/machine"
 )
 
 var (
 	basePath   string
 	composeDir string
+
+	resetBootstrap   bool
+	reapplyBootstrap bool
 )
 
 func main() {
+	// Hidden re-exec mode used by openLogsWindow: stream container logs to
+	// this process's stdout instead of starting the tray.
+	if len(os.Args) >= 2 && os.Args[1] == tailLogsFlag {
+		runTailLogs(os.Args[2:])
+		return
+	}
+
+	flag.BoolVar(&resetBootstrap, "reset-bootstrap", false, "clear bootstrap progress and re-run every step")
+	flag.BoolVar(&reapplyBootstrap, "reapply", false, "re-apply every bootstrap step even if already completed")
+	flag.Parse()
+
 	// Get executable directory
 	ex, err := os.Executable()
 	if err != nil {
 		log.Fatal("Failed to get executable path:", err)
 	}
 	exeDir := filepath.Dir(ex)
-	
+
 	// If executable is in bin/ subdirectory, go up one level
 	if filepath.Base(exeDir) == "bin" {
 		basePath = filepath.Dir(exeDir)
 	} else {
 		basePath = exeDir
 	}
-	
+
 	composeDir = basePath
 	log.Printf("Installation root: %s", basePath)
 	log.Printf("Docker Compose directory: %s", composeDir)
@@ -72,6 +90,8 @@ func onReady() {
 	mLogs := systray.AddMenuItem("View Logs", "")
 	mStop := systray.AddMenuItem("Stop Services", "")
 
+	mHelper := addMacHelperMenuItem()
+
 	systray.AddSeparator()
 
 	mQuit := systray.AddMenuItem("Quit", "")
@@ -80,7 +100,7 @@ func onReady() {
 	go func() {
 		log.Println("Starting RCRT with Podman...")
 
-		if err := startPodmanServices(); err != nil {
+		if err := startPodmanServices(mStatus.SetTitle); err != nil {
 			log.Printf("Error starting services: %v", err)
 			mStatus.SetTitle("Status: Error")
 			return
@@ -89,6 +109,12 @@ func onReady() {
 		mStatus.SetTitle("Status: Running ✓")
 		log.Println("All services started successfully")
 
+		if client, err := newPodmanClient(); err == nil {
+			go watchContainerHealth(context.Background(), client, mStatus.SetTitle)
+		} else {
+			log.Printf("⚠️  Could not connect to Podman API for health events: %v", err)
+		}
+
 		// Open browser after a delay
 		time.Sleep(5 * time.Second)
 		launchBrowser()
@@ -106,9 +132,11 @@ func onReady() {
 
 			case <-mRestart.ClickedCh:
 				mStatus.SetTitle("Status: Restarting...")
+				// stopPodmanServices already confirms every container has
+				// stopped via the Podman API before returning, so there's
+				// no separate event wait needed here.
 				stopPodmanServices()
-				time.Sleep(2 * time.Second)
-				if err := startPodmanServices(); err != nil {
+				if err := startPodmanServices(mStatus.SetTitle); err != nil {
 					mStatus.SetTitle("Status: Error")
 				} else {
 					mStatus.SetTitle("Status: Running ✓")
@@ -119,8 +147,16 @@ func onReady() {
 				stopPodmanServices()
 
 			case <-mLogs.ClickedCh:
-				// Show podman logs
-				exec.Command("podman", "compose", "logs").Start()
+				// Open a live-tailing window for every container Podman
+				// lists.
+				if err := openLogsWindow(); err != nil {
+					log.Printf("⚠️  Could not open logs window: %v", err)
+				}
+
+			case <-helperClickedCh(mHelper):
+				if err := installMacHelper(); err != nil {
+					log.Printf("⚠️  Docker socket bridge install failed: %v", err)
+				}
 
 			case <-mQuit.ClickedCh:
 				systray.Quit()
@@ -129,103 +165,64 @@ func onReady() {
 	}()
 }
 
+// helperClickedCh returns item's click channel, or nil if item is nil
+// (e.g. on platforms without the mac helper menu entry) so the select in
+// onReady can include it unconditionally; a nil channel simply never
+// becomes ready.
+func helperClickedCh(item *systray.MenuItem) chan struct{} {
+	if item == nil {
+		return nil
+	}
+	return item.ClickedCh
+}
+
 func onExit() {
 	log.Println("Shutting down RCRT...")
 	stopPodmanServices()
 	log.Println("Goodbye!")
 }
 
-func startPodmanServices() error {
+func startPodmanServices(onStatus machine.StatusFunc) error {
 	// Ensure .env has valid encryption key
 	if err := ensureValidEnv(); err != nil {
 		log.Printf("⚠️  Environment setup warning: %v", err)
 	}
-	
-	// Find Podman executable
+
+	ctx := context.Background()
+
+	// Find Podman executable, running the first-run installer if it's missing
+	// instead of just logging that the user may need to restart Windows.
 	podmanExe := findPodman()
 	if podmanExe == "" {
-		return fmt.Errorf("Podman is not installed or not found")
+		if err := installPodman(ctx); err != nil {
+			return err
+		}
+		podmanExe = findPodman()
+		if podmanExe == "" {
+			return fmt.Errorf("Podman is not installed or not found")
+		}
 	}
-	
+
 	log.Printf("Using Podman: %s", podmanExe)
 
-	// Initialize Podman machine if needed (first run)
+	// Bring the machine to Running, recovering from a corrupted state with
+	// backoff instead of the ad-hoc substring matching this used to do.
 	log.Println("Checking Podman machine...")
-	listCmd := exec.Command(podmanExe, "machine", "list", "--format", "{{.Name}}")
-	output, _ := listCmd.Output()
-	
-	if len(output) == 0 {
-		// No machine exists, create one
-		log.Println("Initializing Podman machine (first run, ~1-2 minutes)...")
-		log.Println("This downloads a Linux VM and configures it...")
-		
-		// Use --rootful for compatibility with all container types
-		initCmd := exec.Command(podmanExe, "machine", "init", "--now", "--rootful")
-		initCmd.Stdout = os.Stdout
-		initCmd.Stderr = os.Stderr
-		
-		if err := initCmd.Run(); err != nil {
-			log.Printf("⚠️  Machine init error: %v", err)
-			log.Println("   This may be due to WSL not being installed")
-			log.Println("   Please ensure WSL is enabled in Windows Features")
-			log.Println("   Run: wsl --install")
-			return fmt.Errorf("failed to initialize Podman machine: %w", err)
-		}
-		log.Println("✓ Podman machine initialized and started")
-	} else {
-		// Machine exists, try to start it
-		log.Println("Starting Podman machine...")
-		startCmd := exec.Command(podmanExe, "machine", "start")
-		output, err := startCmd.CombinedOutput()
-		outputStr := string(output)
-		
-		if err != nil {
-			// Check if error is because machine is already running (OK)
-			if contains(outputStr, "already running") || contains(outputStr, "already started") {
-				log.Println("✓ Podman machine already running")
-			} else if contains(outputStr, "ssh error") || contains(outputStr, "not transition into running") || contains(outputStr, "pipe instances are busy") {
-				// Machine is corrupted, recreate it
-				log.Println("⚠️  Machine appears corrupted, recreating...")
-				log.Println("   Stopping corrupted machine...")
-				exec.Command(podmanExe, "machine", "stop", "-f").Run()
-				time.Sleep(3 * time.Second)
-				
-				log.Println("   Removing corrupted machine...")
-				removeCmd := exec.Command(podmanExe, "machine", "rm", "-f", "podman-machine-default")
-				removeCmd.Run()
-				time.Sleep(2 * time.Second)
-				
-				log.Println("   Recreating machine (~2 minutes, please wait)...")
-				initCmd := exec.Command(podmanExe, "machine", "init", "--now", "--rootful")
-				initCmd.Stdout = os.Stdout
-				initCmd.Stderr = os.Stderr
-				
-				if err := initCmd.Run(); err != nil {
-					return fmt.Errorf("failed to recreate machine: %w", err)
-				}
-				log.Println("✓ Machine recreated and started successfully")
-			} else {
-				log.Printf("⚠️  Machine start error: %v", err)
-				log.Printf("   Output: %s", outputStr)
-				log.Println("   Continuing anyway...")
-			}
-		} else {
-			log.Println("✓ Podman machine started")
-		}
-		
-		// Wait for machine to be fully ready
-		log.Println("Waiting for machine to be ready...")
-		time.Sleep(10 * time.Second)
+	provider := machine.New(podmanExe)
+	if err := machine.Ensure(ctx, provider, machine.InitOptions{Rootful: true}, machine.DefaultRetryConfig, onStatus); err != nil {
+		log.Printf("⚠️  Machine error: %v", err)
+		log.Println("   This may be due to WSL not being installed")
+		log.Println("   Please ensure WSL is enabled in Windows Features")
+		log.Println("   Run: wsl --install")
+		return fmt.Errorf("failed to bring up Podman machine: %w", err)
 	}
-
-	// Additional wait to ensure Podman socket is ready
-	time.Sleep(5 * time.Second)
+	log.Println("✓ Podman machine running")
 
 	// Import Docker images if needed (first run)
 	imagesDir := filepath.Join(basePath, "images")
 	if _, err := os.Stat(imagesDir); err == nil {
 		log.Println("Importing Docker images (first run, ~2-3 minutes)...")
-		
+
 		// Import all tar files
 		files, _ := filepath.Glob(filepath.Join(imagesDir, "*.tar"))
 		for _, file := range files {
@@ -235,117 +232,97 @@ func startPodmanServices() error {
 				log.Printf("⚠️  Import warning for %s: %v", filepath.Base(file), err)
 			}
 		}
-		
+
 		// Remove images directory after successful import
 		os.RemoveAll(imagesDir)
 		log.Println("✓ All images imported")
 	}
 
-	// Run docker-compose up
-	log.Println("Starting Docker Compose services...")
-	composeCmd := exec.Command(podmanExe, "compose", "up", "-d")
-	composeCmd.Dir = composeDir
-	composeCmd.Stdout = os.Stdout
-	composeCmd.Stderr = os.Stderr
+	client, err := newPodmanClient()
+	if err != nil {
+		return fmt.Errorf("connecting to Podman API: %w", err)
+	}
+
+	containers, err := client.ContainersList(ctx)
+	if err != nil {
+		return fmt.Errorf("listing containers: %w", err)
+	}
+
+	if len(containers) == 0 {
+		// First run: the compose project's containers don't exist yet, and
+		// materializing them from the compose file is compose's job, not
+		// something the libpod REST API does — this is the one shell-out
+		// that has to stay. Every subsequent start/stop/restart goes
+		// through the API instead.
+		log.Println("Starting Docker Compose services...")
+		composeCmd := exec.Command(podmanExe, "compose", "up", "-d")
+		composeCmd.Dir = composeDir
+		composeCmd.Stdout = os.Stdout
+		composeCmd.Stderr = os.Stderr
+
+		// Unseal the KEK from the OS keystore and inject it directly into the
+		// child process's environment instead of ever writing it to disk.
+		if kek, err := resolveKEK(); err != nil {
+			log.Printf("⚠️  Could not resolve encryption key: %v", err)
+		} else {
+			composeCmd.Env = append(os.Environ(), "LOCAL_KEK_BASE64="+kek)
+		}
 
-	if err := composeCmd.Run(); err != nil {
-		return fmt.Errorf("failed to start services: %w", err)
+		if err := composeCmd.Run(); err != nil {
+			return fmt.Errorf("failed to start services: %w", err)
+		}
+	} else {
+		log.Println("Starting existing containers via the Podman API...")
+		for _, c := range containers {
+			if c.State == "running" {
+				continue
+			}
+			if err := client.ContainerStart(ctx, c.ID); err != nil {
+				log.Printf("⚠️  Starting %s: %v", c.ID, err)
+			}
+		}
 	}
 
 	log.Println("✓ Services started")
-	
+
 	// Wait for services to be ready
 	log.Println("Waiting for services to be ready...")
 	time.Sleep(30 * time.Second)
-	
-	// Run bootstrap on first launch (like setup.sh does)
-	if err := runBootstrap(); err != nil {
+
+	// Run bootstrap on every start (like setup.sh did before the Go-native
+	// engine replaced it); steps the state file already records as complete
+	// are skipped
+	if err := runBootstrap(context.Background(), onStatus); err != nil {
 		log.Printf("⚠️  Bootstrap warning: %v", err)
 		log.Println("   You can manually bootstrap later using docker exec")
 	} else {
 		log.Println("✓ Bootstrap complete - system ready!")
-		
+
 		// Restart tools-runner to load model catalog (per setup.sh)
 		log.Println("Restarting tools-runner to load model catalog...")
-		exec.Command(podmanExe, "compose", "restart", "tools-runner").Run()
+		if err := client.ContainerRestart(ctx, "tools-runner"); err != nil {
+			log.Printf("⚠️  Restarting tools-runner: %v", err)
+		}
 		time.Sleep(10 * time.Second)
 	}
-	
-	return nil
-}
 
-func runBootstrap() error {
-	podmanExe := findPodman()
-	if podmanExe == "" {
-		return fmt.Errorf("Podman not found")
-	}
-	
-	// Check if already bootstrapped (check for marker file)
-	markerFile := filepath.Join(basePath, ".bootstrapped")
-	if _, err := os.Stat(markerFile); err == nil {
-		log.Println("System already bootstrapped, skipping...")
-		return nil
-	}
-	
-	log.Println("🌱 Bootstrapping RCRT system (first run)...")
-	log.Println("   This creates agents, tools, and system configuration...")
-	
-	// Run bootstrap from host (bootstrap-breadcrumbs bundled in installer)
-	bootstrapDir := filepath.Join(basePath, "bootstrap-breadcrumbs")
-	bootstrapScript := filepath.Join(bootstrapDir, "bootstrap.js")
-	
-	// Check if bootstrap directory exists
-	if _, err := os.Stat(bootstrapScript); err != nil {
-		return fmt.Errorf("bootstrap script not found: %s", bootstrapScript)
-	}
-	
-	// Find Node.js (bundled or system)
-	nodeExe := "node" // System Node.js
-	
-	bootstrapCmd := exec.Command(nodeExe, bootstrapScript)
-	bootstrapCmd.Dir = bootstrapDir
-	
-	// Set environment variables for bootstrap
-	// Use host's localhost to connect to Podman-exposed ports
-	bootstrapCmd.Env = append(os.Environ(),
-		"RCRT_BASE_URL=http://localhost:8081", // External port mapping
-		"OWNER_ID=00000000-0000-0000-0000-000000000001",
-		"AGENT_ID=00000000-0000-0000-0000-0000000000aa",
-	)
-	
-	bootstrapCmd.Stdout = os.Stdout
-	bootstrapCmd.Stderr = os.Stderr
-	
-	if err := bootstrapCmd.Run(); err != nil {
-		return fmt.Errorf("bootstrap script failed: %w", err)
-	}
-	
-	log.Println("✓ Bootstrap script completed")
-	log.Println("   Waiting for bootstrap tools to execute...")
-	time.Sleep(20 * time.Second)
-	
-	// Create marker file
-	os.WriteFile(markerFile, []byte("bootstrapped"), 0644)
-	log.Println("✓ Bootstrap marker created")
-	
 	return nil
 }
 
-
 // findPodman finds Podman executable by checking PATH and common locations
 func findPodman() string {
 	// Try PATH first
 	if path, err := exec.LookPath("podman"); err == nil {
 		return path
 	}
-	
+
 	// Check common installation locations
 	podmanPaths := []string{
 		"C:\\Program Files\\RedHat\\Podman\\podman.exe",
 		"C:\\Program Files (x86)\\RedHat\\Podman\\podman.exe",
 		filepath.Join(os.Getenv("LOCALAPPDATA"), "Podman", "podman.exe"),
 	}
-	
+
 	for _, path := range podmanPaths {
 		if _, err := os.Stat(path); err == nil {
 			log.Printf("Found Podman at: %s", path)
@@ -355,48 +332,58 @@ func findPodman() string {
 			return path
 		}
 	}
-	
+
 	log.Println("⚠️  Podman not found in PATH or standard locations")
 	log.Println("   Installer should have installed Podman CLI")
 	log.Println("   You may need to restart Windows to update PATH")
-	
+
 	return ""
 }
 
-func contains(s, substr string) bool {
-	return len(s) > 0 && len(substr) > 0 && (s == substr || len(s) >= len(substr) && (s[:len(substr)] == substr || s[len(s)-len(substr):] == substr || findInString(s, substr)))
-}
+// stopPodmanServices stops every running container via the Podman API and
+// confirms via ContainersList that none are left running before
+// returning, instead of shelling out to `podman compose down` and hoping
+// a single "die" event means the whole project settled.
+func stopPodmanServices() {
+	log.Println("Stopping services...")
+
+	client, err := newPodmanClient()
+	if err != nil {
+		log.Printf("⚠️  Could not connect to Podman API to stop services: %v", err)
+		return
+	}
+
+	ctx := context.Background()
+	containers, err := client.ContainersList(ctx)
+	if err != nil {
+		log.Printf("⚠️  Could not list containers: %v", err)
+		return
+	}
 
-func findInString(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
+	for _, c := range containers {
+		if c.State != "running" {
+			continue
+		}
+		if err := client.ContainerStop(ctx, c.ID); err != nil {
+			log.Printf("⚠️  Stopping %s: %v", c.ID, err)
 		}
 	}
-	return false
-}
 
-func stopPodmanServices() {
-	log.Println("Stopping services...")
-	podmanExe := findPodman()
-	if podmanExe == "" {
-		podmanExe = "podman" // Fallback
+	if !waitContainersStopped(ctx, client, 15*time.Second) {
+		log.Println("⚠️  Timed out waiting for containers to stop")
 	}
-	cmd := exec.Command(podmanExe, "compose", "down")
-	cmd.Dir = composeDir
-	cmd.Run()
 }
 
 func launchBrowser() {
 	extensionPath := filepath.Join(basePath, "extension")
-	
+
 	// Check for Helium in multiple locations
 	browserPaths := []string{
 		filepath.Join(basePath, "browser", "helium_0.5.8.1_x64-windows", "chrome.exe"),
 		filepath.Join(basePath, "browser", "chrome.exe"),
 		filepath.Join(basePath, "browser", "helium.exe"),
 	}
-	
+
 	for _, browserPath := range browserPaths {
 		if _, err := os.Stat(browserPath); err == nil {
 			log.Printf("Launching Helium from: %s", browserPath)
@@ -412,11 +399,10 @@ func launchBrowser() {
 			}
 		}
 	}
-	
+
 	// Fallback to default browser
 	log.Println("Helium not found, opening in default browser")
 	open.Run("http://localhost:8082")
 }
 
 // getIcon moved to icon.go
-
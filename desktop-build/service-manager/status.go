@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"rcrt/internal/podmanapi"
+)
+
+// newPodmanClient connects to the Podman REST API over the platform's
+// machine socket.
+func newPodmanClient() (*podmanapi.Client, error) {
+	return podmanapi.NewClient()
+}
+
+// waitContainersStopped polls ContainersList until no container is
+// running or timeout elapses. A single "die" event isn't a reliable
+// signal that a multi-container compose project has actually settled —
+// polling the list confirms every container, not just the first one to
+// report.
+func waitContainersStopped(ctx context.Context, client *podmanapi.Client, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		containers, err := client.ContainersList(ctx)
+		if err != nil {
+			return false
+		}
+		running := 0
+		for _, c := range containers {
+			if c.State == "running" {
+				running++
+			}
+		}
+		if running == 0 {
+			return true
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	return false
+}
+
+// watchContainerHealth follows the Podman event stream so the tray's
+// "Status:" label reflects real container health, rather than assuming
+// things are fine after a fixed Sleep(). The stream reconnects if it ends
+// on its own (the Podman API, a machine hiccup) so health tracking doesn't
+// silently go stale for the rest of the process's life; ctx cancellation
+// is the only thing that stops it for good.
+func watchContainerHealth(ctx context.Context, client *podmanapi.Client, onStatus func(string)) {
+	for ctx.Err() == nil {
+		for ev := range client.EventsStream(ctx) {
+			if ev.Type != "container" {
+				continue
+			}
+			switch ev.Status {
+			case "die":
+				name := ev.Actor.Attributes["name"]
+				log.Printf("⚠️  Container %s died", name)
+				onStatus(fmt.Sprintf("Status: Degraded (%s died)", name))
+			case "start":
+				containers, err := client.ContainersList(ctx)
+				if err != nil {
+					continue
+				}
+				running := 0
+				for _, c := range containers {
+					if c.State == "running" {
+						running++
+					}
+				}
+				onStatus(fmt.Sprintf("Status: Running ✓ (%d/%d containers)", running, len(containers)))
+			}
+		}
+		if ctx.Err() == nil {
+			log.Println("podmanapi: events stream ended, reconnecting")
+			time.Sleep(time.Second)
+		}
+	}
+}